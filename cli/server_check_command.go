@@ -15,15 +15,29 @@ package cli
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/choria-io/fisk"
+	"github.com/choria-io/fisk/units"
+	"github.com/dustin/go-humanize"
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jwt/v2"
@@ -31,6 +45,7 @@ import (
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/natscli/monitor"
 	"github.com/nats-io/nkeys"
+	"github.com/nats-io/nuid"
 )
 
 type SrvCheckCmd struct {
@@ -38,14 +53,21 @@ type SrvCheckCmd struct {
 	connectCritical time.Duration
 	rttWarning      time.Duration
 	rttCritical     time.Duration
+	rttSamples      int
+	rttPercentile   int
 	reqWarning      time.Duration
 	reqCritical     time.Duration
 
 	sourcesStream            string
+	sourcesLagWarn           uint64
+	sourcesLagWarnIsSet      bool
 	sourcesLagCritical       uint64
 	sourcesLagCriticalIsSet  bool
+	sourcesSeenWarn          time.Duration
+	sourcesSeenWarnIsSet     bool
 	sourcesSeenCritical      time.Duration
 	sourcesSeenCriticalIsSet bool
+	sourcesRequireMirror     bool
 	sourcesMinSources        int
 	sourcesMinSourcesIsSet   bool
 	sourcesMaxSources        int
@@ -58,20 +80,52 @@ type SrvCheckCmd struct {
 	subjectsWarnIsSet        bool
 	subjectsCrit             int
 	subjectsCritIsSet        bool
+	streamBytesPctWarn       int
+	streamBytesPctWarnIsSet  bool
+	streamBytesPctCrit       int
+	streamBytesPctCritIsSet  bool
+	streamCreated            string
+	streamAllowDirect        bool
+	streamAllowDirectIsSet   bool
+	streamMirrorDirect       bool
+	streamMirrorDirectIsSet  bool
+	streamAllowRollup        bool
+	streamAllowRollupIsSet   bool
+	streamRollupSubject      string
+	streamStorage            string
+	expectMetadata           map[string]string
 
 	consumerName                        string
+	consumerAckOutstandingWarn          int
+	consumerAckOutstandingWarnIsSet     bool
 	consumerAckOutstandingCritical      int
 	consumerAckOutstandingCriticalIsSet bool
+	consumerAckPendingWarn              int
+	consumerAckPendingWarnIsSet         bool
+	consumerAckPendingCrit              int
+	consumerAckPendingCritIsSet         bool
 	consumerWaitingCritical             int
 	consumerWaitingCriticalIsSet        bool
+	consumerUnprocessedWarn             int
+	consumerUnprocessedWarnIsSet        bool
 	consumerUnprocessedCritical         int
 	consumerUnprocessedCriticalIsSet    bool
 	consumerLastDeliveryCritical        time.Duration
 	consumerLastDeliveryCriticalIsSet   bool
 	consumerLastAckCritical             time.Duration
 	consumerLastAckCriticalIsSet        bool
+	consumerRedeliveryWarn              int
+	consumerRedeliveryWarnIsSet         bool
 	consumerRedeliveryCritical          int
 	consumerRedeliveryCriticalIsSet     bool
+	consumerBackoff                     []time.Duration
+	consumerPlacement                   bool
+	consumerPaused                      bool
+	consumerPausedIsSet                 bool
+	consumerPauseRemainingWarn          time.Duration
+	consumerDurable                     bool
+	consumerFilterOverlap               bool
+	consumerExists                      bool
 
 	raftExpect            int
 	raftExpectIsSet       bool
@@ -79,6 +133,10 @@ type SrvCheckCmd struct {
 	raftLagCriticalIsSet  bool
 	raftSeenCritical      time.Duration
 	raftSeenCriticalIsSet bool
+	raftSpreadWarn        uint64
+	raftSpreadWarnIsSet   bool
+	raftSpreadCrit        uint64
+	raftSpreadCritIsSet   bool
 
 	jsMemWarn             int
 	jsMemCritical         int
@@ -92,21 +150,28 @@ type SrvCheckCmd struct {
 	jsReplicaSeenCritical time.Duration
 	jsReplicaLagCritical  uint64
 
-	srvName        string
-	srvCPUWarn     int
-	srvCPUCrit     int
-	srvMemWarn     int
-	srvMemCrit     int
-	srvConnWarn    int
-	srvConnCrit    int
-	srvSubsWarn    int
-	srvSubCrit     int
-	srvUptimeWarn  time.Duration
-	srvUptimeCrit  time.Duration
-	srvAuthRequire bool
-	srvTLSRequired bool
-	srvJSRequired  bool
-	srvURL         *url.URL
+	srvName          string
+	srvCPUWarn       int
+	srvCPUCrit       int
+	srvMemWarn       int
+	srvMemCrit       int
+	srvConnWarn      int
+	srvConnCrit      int
+	srvSubsWarn      int
+	srvSubCrit       int
+	srvUptimeWarn    time.Duration
+	srvUptimeCrit    time.Duration
+	srvAuthRequire   bool
+	srvTLSRequired   bool
+	srvJSRequired    bool
+	srvJSDomain      string
+	srvWriteDeadline time.Duration
+	srvMaxPending    units.Base2Bytes
+	srvWSTLS         bool
+	srvWSOrigins     []string
+	srvWSURL         string
+	srvMQTTTLS       string
+	srvURL           *url.URL
 
 	msgSubject  string
 	msgAgeWarn  time.Duration
@@ -114,6 +179,24 @@ type SrvCheckCmd struct {
 	msgRegexp   *regexp.Regexp
 	msgBodyAsTs bool
 
+	mapAccount string
+	mapSource  string
+	mapDests   map[string]string
+
+	acctName     string
+	acctConnWarn int
+	acctConnCrit int
+	acctSubsWarn int
+	acctSubsCrit int
+
+	visStreams []string
+	visBuckets []string
+
+	hdrNames       []string
+	hdrCount       int
+	hdrMissingWarn int
+	hdrMissingCrit int
+
 	kvBucket                 string
 	kvValuesCrit             int
 	kvValuesWarn             int
@@ -123,6 +206,86 @@ type SrvCheckCmd struct {
 	credentialRequiresExpire bool
 	credential               string
 
+	knownServers []string
+
+	reqSubject string
+	reqPayload string
+
+	objBucket  string
+	objName    string
+	objMaxSize units.Base2Bytes
+
+	objObjectsWarn int
+	objObjectsCrit int
+	objBytesWarn   units.Base2Bytes
+	objBytesCrit   units.Base2Bytes
+	objMinSize     units.Base2Bytes
+
+	tlsCA           string
+	tlsHostname     string
+	tlsValidityWarn time.Duration
+	tlsValidityCrit time.Duration
+	tlsClientCert   bool
+
+	routesIgnore []string
+
+	leafRemotes []string
+	leafsWarn   int
+	leafsCrit   int
+
+	gwRemotes      []string
+	gwOutboundWarn int
+	gwOutboundCrit int
+	gwInboundWarn  int
+	gwInboundCrit  int
+
+	availAttempts int
+	availInterval time.Duration
+	availWarn     int
+	availCrit     int
+
+	hsAttempts int
+	hsSlow     time.Duration
+	hsFailWarn int
+	hsFailCrit int
+
+	pubSubject  string
+	pubWarning  time.Duration
+	pubCritical time.Duration
+	pubPurge    bool
+
+	respIDHeader string
+	respRequests int
+	respWarn     int
+	respCrit     int
+
+	throughputInterval     time.Duration
+	throughputInMsgsWarn   int
+	throughputInMsgsCrit   int
+	throughputInBytesWarn  units.Base2Bytes
+	throughputInBytesCrit  units.Base2Bytes
+	throughputOutMsgsWarn  int
+	throughputOutMsgsCrit  int
+	throughputOutBytesWarn units.Base2Bytes
+	throughputOutBytesCrit units.Base2Bytes
+	msgRateInterval        time.Duration
+	msgRateMinWarn         int
+	msgRateMinCrit         int
+	msgRateMaxWarn         int
+	msgRateMaxCrit         int
+	connGrowthSamples      int
+	connGrowthInterval     time.Duration
+	connGrowthWarn         int
+	connGrowthCrit         int
+
+	assetsWarn int
+	assetsCrit int
+
+	namingStream         *regexp.Regexp
+	namingConsumer       *regexp.Regexp
+	namingViolationsWarn int
+	namingViolationsCrit int
+
 	useMetadata bool
 }
 
@@ -140,9 +303,137 @@ func configureServerCheckCommand(srv *fisk.CmdClause) {
 	conn.Flag("connect-critical", "Critical threshold to allow for establishing connections").Default("1s").PlaceHolder("DURATION").DurationVar(&c.connectCritical)
 	conn.Flag("rtt-warn", "Warning threshold to allow for server RTT").Default("500ms").PlaceHolder("DURATION").DurationVar(&c.rttWarning)
 	conn.Flag("rtt-critical", "Critical threshold to allow for server RTT").Default("1s").PlaceHolder("DURATION").DurationVar(&c.rttCritical)
+	conn.Flag("rtt-samples", "Number of RTT samples to take").Default("1").IntVar(&c.rttSamples)
+	conn.Flag("rtt-percentile", "Percentile of the RTT samples to check against thresholds").Default("100").IntVar(&c.rttPercentile)
 	conn.Flag("req-warn", "Warning threshold to allow for full round trip test").PlaceHolder("DURATION").Default("500ms").DurationVar(&c.reqWarning)
 	conn.Flag("req-critical", "Critical threshold to allow for full round trip test").PlaceHolder("DURATION").Default("1s").DurationVar(&c.reqCritical)
 
+	known := check.Command("servers", "Checks that only known servers are connected to the system").Action(c.checkKnownServersAction)
+	known.HelpLong(`Discovers all servers using the system account and reports any server whose name or
+ID is not in the list of known servers, this can surface rogue or misconfigured servers
+joining the cluster.`)
+	known.Flag("known", "Name or ID of a server that is expected to be connected, can be repeated").Required().StringsVar(&c.knownServers)
+
+	req := check.Command("request", "Checks that a service responds to requests").Alias("req").Action(c.checkRequestAction)
+	req.HelpLong(`Sends a request to a service subject and verifies a response is received within the
+timeout set using --timeout.  When the subject is a service imported from another account
+this confirms the import and export are correctly wired between the accounts.`)
+	req.Flag("subject", "The subject to send the request to").Required().StringVar(&c.reqSubject)
+	req.Flag("payload", "The payload to send in the request").StringVar(&c.reqPayload)
+	req.Flag("content", "Regular expression to check the response against").PlaceHolder("REGEX").RegexpVar(&c.msgRegexp)
+	req.Flag("req-warn", "Warning threshold for the time taken to receive a response").PlaceHolder("DURATION").Default("500ms").DurationVar(&c.reqWarning)
+	req.Flag("req-critical", "Critical threshold for the time taken to receive a response").PlaceHolder("DURATION").Default("1s").DurationVar(&c.reqCritical)
+
+	obj := check.Command("object", "Checks a NATS Object Store object").Action(c.checkObjectAction)
+	obj.HelpLong(`Reads the object fully and compares its SHA-256 digest to the digest stored in
+the object metadata.`)
+	obj.Flag("bucket", "The bucket holding the object").Required().StringVar(&c.objBucket)
+	obj.Flag("object", "The object to verify").Required().StringVar(&c.objName)
+	obj.Flag("max-size", "Only verify the object if it is smaller than this size").PlaceHolder("BYTES").BytesVar(&c.objMaxSize)
+
+	obsc := check.Command("objectstore", "Checks a NATS Object Store bucket").Action(c.checkObjectStoreAction)
+	obsc.HelpLong(`Checks that the bucket exists and optionally that an object is present.  When the
+critical thresholds are lower than the warning thresholds low values are considered
+a problem, useful to detect buckets that were unexpectedly emptied.`)
+	obsc.Flag("bucket", "The bucket to check").Required().StringVar(&c.objBucket)
+	obsc.Flag("object", "Requires an object to be present in the bucket").StringVar(&c.objName)
+	obsc.Flag("min-size", "Critical if the object given in --object is smaller than this size").PlaceHolder("BYTES").BytesVar(&c.objMinSize)
+	obsc.Flag("max-size", "Critical if the object given in --object is larger than this size").PlaceHolder("BYTES").BytesVar(&c.objMaxSize)
+	obsc.Flag("objects-warn", "Warning threshold for number of objects holding data in the bucket").Default("-1").IntVar(&c.objObjectsWarn)
+	obsc.Flag("objects-critical", "Critical threshold for number of objects holding data in the bucket").Default("-1").IntVar(&c.objObjectsCrit)
+	obsc.Flag("bytes-warn", "Warning threshold for bytes stored in the bucket").PlaceHolder("BYTES").Default("-1B").BytesVar(&c.objBytesWarn)
+	obsc.Flag("bytes-critical", "Critical threshold for bytes stored in the bucket").PlaceHolder("BYTES").Default("-1B").BytesVar(&c.objBytesCrit)
+
+	tlsc := check.Command("tls", "Checks the server certificate chain and certificate expiry").Action(c.checkTLSAction)
+	tlsc.HelpLong(`Connects to the server and verifies the presented certificate chain against the
+CA bundle given in --ca, the CA configured in the context or the system roots, this
+detects servers rotated to certificates from an unexpected CA.  The certificate has to
+be valid for --hostname, defaulting to the hostname being connected to.
+
+The expiry is always reported, also for certificates that fail verification, and the
+--validity thresholds alert on certificates that expire soon, with --client-cert
+the client certificate configured in the context is checked as well.`)
+	tlsc.Flag("ca", "CA bundle the server certificate should chain to").ExistingFileVar(&c.tlsCA)
+	tlsc.Flag("hostname", "Hostname the server certificate should be valid for, defaults to the server hostname").StringVar(&c.tlsHostname)
+	tlsc.Flag("validity-warn", "Warning threshold for time before certificate expiry").PlaceHolder("DURATION").DurationVar(&c.tlsValidityWarn)
+	tlsc.Flag("validity-critical", "Critical threshold for time before certificate expiry").PlaceHolder("DURATION").DurationVar(&c.tlsValidityCrit)
+	tlsc.Flag("client-cert", "Also checks the expiry of the client certificate").UnNegatableBoolVar(&c.tlsClientCert)
+
+	routes := check.Command("routes", "Checks that all configured cluster routes are connected").Action(c.checkRoutesAction)
+	routes.HelpLong(`Compares the route URLs configured on a server with its connected routes, a route
+counts as connected whichever server initiated it.  Configurations often list every
+server in the cluster including the server being checked, use --ignore to skip its
+own URL when it cannot be detected automatically.`)
+	routes.Flag("name", "Server name to require in the result").Required().StringVar(&c.srvName)
+	routes.Flag("ignore", "Configured route URL to ignore, can be repeated").PlaceHolder("URL").StringsVar(&c.routesIgnore)
+
+	leafs := check.Command("leafnodes", "Checks the leafnode connections of a server").Alias("leafz").Action(c.checkLeafnodesAction)
+	leafs.HelpLong(`Checks the number of leafnode connections on a server, in a hub and spoke topology
+this is usually the hub, and optionally that leafnodes from specific servers are
+connected.`)
+	leafs.Flag("name", "Server name to require in the result").Required().StringVar(&c.srvName)
+	leafs.Flag("remote", "Name of a server expected to be connected as a leafnode, can be repeated").PlaceHolder("NAME").StringsVar(&c.leafRemotes)
+	leafs.Flag("leafs-warn", "Warn if fewer than this many leafnodes are connected").Default("-1").IntVar(&c.leafsWarn)
+	leafs.Flag("leafs-critical", "Critical if fewer than this many leafnodes are connected").Default("-1").IntVar(&c.leafsCrit)
+
+	gws := check.Command("gateways", "Checks the gateway connections of a server in a super cluster").Alias("gatewayz").Action(c.checkGatewaysAction)
+	gws.HelpLong(`Checks the outbound and inbound gateway connections of a server, configured gateways
+that are not connected are always critical.`)
+	gws.Flag("name", "Server name to require in the result").Required().StringVar(&c.srvName)
+	gws.Flag("gateway", "Name of a gateway expected to be connected, can be repeated").PlaceHolder("NAME").StringsVar(&c.gwRemotes)
+	gws.Flag("outbound-warn", "Warn if fewer than this many outbound gateways are connected").Default("-1").IntVar(&c.gwOutboundWarn)
+	gws.Flag("outbound-critical", "Critical if fewer than this many outbound gateways are connected").Default("-1").IntVar(&c.gwOutboundCrit)
+	gws.Flag("inbound-warn", "Warn if fewer than this many inbound gateway connections are connected").Default("-1").IntVar(&c.gwInboundWarn)
+	gws.Flag("inbound-critical", "Critical if fewer than this many inbound gateway connections are connected").Default("-1").IntVar(&c.gwInboundCrit)
+
+	avail := check.Command("availability", "Checks the availability of a Stream or Consumer over a short window").Action(c.checkAvailabilityAction)
+	avail.HelpLong(`Repeatedly loads the Stream, or Consumer when --consumer is given, and reports the
+percentage of attempts that succeeded.  This is useful during rolling restarts where a
+single point in time check could miss short periods of unavailability.`)
+	avail.Flag("stream", "The stream to check").Required().StringVar(&c.sourcesStream)
+	avail.Flag("consumer", "The consumer to check").StringVar(&c.consumerName)
+	avail.Flag("attempts", "Number of attempts to make").Default("10").IntVar(&c.availAttempts)
+	avail.Flag("interval", "Time to wait between attempts").Default("1s").PlaceHolder("DURATION").DurationVar(&c.availInterval)
+	avail.Flag("availability-warn", "Warn if fewer than this percentage of attempts succeed").Default("-1").IntVar(&c.availWarn)
+	avail.Flag("availability-critical", "Critical if fewer than this percentage of attempts succeed").Default("100").IntVar(&c.availCrit)
+
+	hs := check.Command("handshake", "Checks the reliability of repeated connections to the server").Action(c.checkHandshakeAction)
+	hs.HelpLong(`Connects and disconnects --attempts times in quick succession, connections that fail
+or take longer than --slow to complete count as failed handshakes.`)
+	hs.Flag("attempts", "Number of connections to make").Default("5").IntVar(&c.hsAttempts)
+	hs.Flag("slow", "Handshakes taking longer than this are considered failed").PlaceHolder("DURATION").Default("1s").DurationVar(&c.hsSlow)
+	hs.Flag("failed-warn", "Warning threshold for failed handshakes, in percent").Default("-1").IntVar(&c.hsFailWarn)
+	hs.Flag("failed-critical", "Critical threshold for failed handshakes, in percent").Default("0").IntVar(&c.hsFailCrit)
+
+	pub := check.Command("publish", "Checks the time taken for JetStream to acknowledge a published message").Alias("pub").Action(c.checkPublishAction)
+	pub.HelpLong(`Publishes a message to a subject bound to a stream and measures the time taken to
+receive the JetStream acknowledgement.  Use a subject dedicated to this check.`)
+	pub.Flag("subject", "The subject to publish to").Required().StringVar(&c.pubSubject)
+	pub.Flag("payload", "The payload to publish").StringVar(&c.reqPayload)
+	pub.Flag("pub-warn", "Warning threshold for the time taken to receive the acknowledgement").PlaceHolder("DURATION").Default("500ms").DurationVar(&c.pubWarning)
+	pub.Flag("pub-critical", "Critical threshold for the time taken to receive the acknowledgement").PlaceHolder("DURATION").Default("1s").DurationVar(&c.pubCritical)
+	pub.Flag("purge", "Purge messages on the subject after publishing").UnNegatableBoolVar(&c.pubPurge)
+
+	rtrip := check.Command("roundtrip", "Checks the time taken for a published message to be delivered by JetStream").Action(c.checkRoundtripAction)
+	rtrip.HelpLong(`Publishes a message to a subject bound to a stream and measures the time taken for
+an ephemeral consumer to receive it.  The consumer only receives new messages and is
+removed after the check, use a subject dedicated to this check.`)
+	rtrip.Flag("subject", "The subject to publish to").Required().StringVar(&c.pubSubject)
+	rtrip.Flag("payload", "The payload to publish").StringVar(&c.reqPayload)
+	rtrip.Flag("roundtrip-warn", "Warning threshold for the time taken to receive the message").PlaceHolder("DURATION").Default("500ms").DurationVar(&c.pubWarning)
+	rtrip.Flag("roundtrip-critical", "Critical threshold for the time taken to receive the message").PlaceHolder("DURATION").Default("1s").DurationVar(&c.pubCritical)
+	rtrip.Flag("purge", "Purge messages on the subject after the check").UnNegatableBoolVar(&c.pubPurge)
+
+	resp := check.Command("responders", "Checks the number of distinct responders on a service subject").Action(c.checkRespondersAction)
+	resp.HelpLong(`Sends --requests requests to a service subject and counts the distinct responders
+using a header set by each responder that uniquely identifies it.`)
+	resp.Flag("subject", "The subject to send the requests to").Required().StringVar(&c.reqSubject)
+	resp.Flag("payload", "The payload to send in the requests").StringVar(&c.reqPayload)
+	resp.Flag("id-header", "The response header identifying each responder").Required().StringVar(&c.respIDHeader)
+	resp.Flag("requests", "Number of requests to send").Default("10").IntVar(&c.respRequests)
+	resp.Flag("responders-warn", "Warn if fewer than this many distinct responders respond").Default("-1").IntVar(&c.respWarn)
+	resp.Flag("responders-critical", "Critical if fewer than this many distinct responders respond").Default("1").IntVar(&c.respCrit)
+
 	stream := check.Command("stream", "Checks the health of mirrored streams, streams with sources or clustered streams").Action(c.checkStream)
 	stream.HelpLong(`These settings can be set using Stream Metadata in the following form:
 
@@ -150,17 +441,31 @@ func configureServerCheckCommand(srv *fisk.CmdClause) {
 
 When set these settings will be used, but can be overridden using --lag-critical.`)
 	stream.Flag("stream", "The streams to check").Required().StringVar(&c.sourcesStream)
+	stream.Flag("lag-warn", "Warning threshold to allow for lag on any source or mirror").PlaceHolder("MSGS").IsSetByUser(&c.sourcesLagWarnIsSet).Uint64Var(&c.sourcesLagWarn)
 	stream.Flag("lag-critical", "Critical threshold to allow for lag on any source or mirror").PlaceHolder("MSGS").IsSetByUser(&c.sourcesLagCriticalIsSet).Uint64Var(&c.sourcesLagCritical)
+	stream.Flag("seen-warn", "Warning threshold for how long ago the source or mirror should have been seen").PlaceHolder("DURATION").IsSetByUser(&c.sourcesSeenWarnIsSet).DurationVar(&c.sourcesSeenWarn)
 	stream.Flag("seen-critical", "Critical threshold for how long ago the source or mirror should have been seen").PlaceHolder("DURATION").IsSetByUser(&c.sourcesSeenCriticalIsSet).DurationVar(&c.sourcesSeenCritical)
+	stream.Flag("mirror", "Critical if the stream is not a mirror").UnNegatableBoolVar(&c.sourcesRequireMirror)
 	stream.Flag("min-sources", "Minimum number of sources to expect").PlaceHolder("SOURCES").Default("1").IsSetByUser(&c.sourcesMinSourcesIsSet).IntVar(&c.sourcesMinSources)
 	stream.Flag("max-sources", "Maximum number of sources to expect").PlaceHolder("SOURCES").Default("1").IsSetByUser(&c.sourcesMaxSourcesIsSet).IntVar(&c.sourcesMaxSources)
 	stream.Flag("peer-expect", "Number of cluster replicas to expect").Default("1").PlaceHolder("SERVERS").IsSetByUser(&c.raftExpectIsSet).IntVar(&c.raftExpect)
 	stream.Flag("peer-lag-critical", "Critical threshold to allow for cluster peer lag").PlaceHolder("OPS").IsSetByUser(&c.raftLagCriticalIsSet).Uint64Var(&c.raftLagCritical)
 	stream.Flag("peer-seen-critical", "Critical threshold for how long ago a cluster peer should have been seen").PlaceHolder("DURATION").IsSetByUser(&c.raftSeenCriticalIsSet).Default("10s").DurationVar(&c.raftSeenCritical)
+	stream.Flag("peer-spread-warn", "Warning threshold for the lag between the most and least advanced cluster peer").PlaceHolder("OPS").IsSetByUser(&c.raftSpreadWarnIsSet).Uint64Var(&c.raftSpreadWarn)
+	stream.Flag("peer-spread-critical", "Critical threshold for the lag between the most and least advanced cluster peer").PlaceHolder("OPS").IsSetByUser(&c.raftSpreadCritIsSet).Uint64Var(&c.raftSpreadCrit)
 	stream.Flag("msgs-warn", "Warn if there are fewer than this many messages in the stream").PlaceHolder("MSGS").IsSetByUser(&c.streamMessagesWarnIsSet).Uint64Var(&c.streamMessagesWarn)
 	stream.Flag("msgs-critical", "Critical if there are fewer than this many messages in the stream").PlaceHolder("MSGS").IsSetByUser(&c.streamMessagesCritIsSet).Uint64Var(&c.streamMessagesCrit)
 	stream.Flag("subjects-warn", "Critical threshold for subjects in the stream").PlaceHolder("SUBJECTS").Default("-1").IsSetByUser(&c.subjectsWarnIsSet).IntVar(&c.subjectsWarn)
 	stream.Flag("subjects-critical", "Warning threshold for subjects in the stream").PlaceHolder("SUBJECTS").Default("-1").IsSetByUser(&c.subjectsCritIsSet).IntVar(&c.subjectsCrit)
+	stream.Flag("bytes-pct-warn", "Warning threshold for stored bytes as a percentage of the stream max bytes").PlaceHolder("PERCENT").Default("-1").IsSetByUser(&c.streamBytesPctWarnIsSet).IntVar(&c.streamBytesPctWarn)
+	stream.Flag("bytes-pct-critical", "Critical threshold for stored bytes as a percentage of the stream max bytes").PlaceHolder("PERCENT").Default("-1").IsSetByUser(&c.streamBytesPctCritIsSet).IntVar(&c.streamBytesPctCrit)
+	stream.Flag("created", "Critical if the stream was not created at this time, detects streams that were deleted and recreated").PlaceHolder("RFC3339").StringVar(&c.streamCreated)
+	stream.Flag("allow-direct", "Checks that direct get is enabled, --no-allow-direct checks it is disabled").IsSetByUser(&c.streamAllowDirectIsSet).BoolVar(&c.streamAllowDirect)
+	stream.Flag("mirror-direct", "Checks that direct get from the mirror is enabled, --no-mirror-direct checks it is disabled").IsSetByUser(&c.streamMirrorDirectIsSet).BoolVar(&c.streamMirrorDirect)
+	stream.Flag("allow-rollup", "Checks that message rollups are allowed, --no-allow-rollup checks they are not allowed").IsSetByUser(&c.streamAllowRollupIsSet).BoolVar(&c.streamAllowRollup)
+	stream.Flag("rollup-subject", "Checks that every subject matching this filter holds a single rolled up message").PlaceHolder("SUBJECT").StringVar(&c.streamRollupSubject)
+	stream.Flag("storage", "Checks that the stream uses this storage type").PlaceHolder("TYPE").EnumVar(&c.streamStorage, "file", "memory")
+	stream.Flag("expect-metadata", "Checks that the stream metadata holds KEY=VALUE, can be repeated").PlaceHolder("KEY=VALUE").StringMapVar(&c.expectMetadata)
 	stream.Flag("metadata", "Sets monitoring thresholds from Stream metadata").Default("true").BoolVar(&c.useMetadata)
 
 	consumer := check.Command("consumer", "Checks the health of a consumer").Action(c.checkConsumer)
@@ -171,14 +476,44 @@ When set these settings will be used, but can be overridden using --lag-critical
 When set these settings will be used, but can be overridden using --waiting-critical.`)
 	consumer.Flag("stream", "The streams to check").Required().StringVar(&c.sourcesStream)
 	consumer.Flag("consumer", "The consumer to check").Required().StringVar(&c.consumerName)
+	consumer.Flag("exists", "Only checks that the consumer exists, skipping all health checks").UnNegatableBoolVar(&c.consumerExists)
+	consumer.Flag("outstanding-ack-warn", "Warning threshold for the number of outstanding acks").Default("-1").IsSetByUser(&c.consumerAckOutstandingWarnIsSet).IntVar(&c.consumerAckOutstandingWarn)
 	consumer.Flag("outstanding-ack-critical", "Maximum number of outstanding acks to allow").Default("-1").IsSetByUser(&c.consumerAckOutstandingCriticalIsSet).IntVar(&c.consumerAckOutstandingCritical)
+	consumer.Flag("ack-pending-warn", "Warning threshold for outstanding acks as a percentage of max ack pending").Default("-1").IsSetByUser(&c.consumerAckPendingWarnIsSet).IntVar(&c.consumerAckPendingWarn)
+	consumer.Flag("ack-pending-critical", "Critical threshold for outstanding acks as a percentage of max ack pending").Default("-1").IsSetByUser(&c.consumerAckPendingCritIsSet).IntVar(&c.consumerAckPendingCrit)
 	consumer.Flag("waiting-critical", "Maximum number of waiting pulls to allow").Default("-1").IsSetByUser(&c.consumerWaitingCriticalIsSet).IntVar(&c.consumerWaitingCritical)
+	consumer.Flag("unprocessed-warn", "Warning threshold for the number of unprocessed messages").Default("-1").IsSetByUser(&c.consumerUnprocessedWarnIsSet).IntVar(&c.consumerUnprocessedWarn)
 	consumer.Flag("unprocessed-critical", "Maximum number of unprocessed messages to allow").Default("-1").IsSetByUser(&c.consumerUnprocessedCriticalIsSet).IntVar(&c.consumerUnprocessedCritical)
 	consumer.Flag("last-delivery-critical", "Time to allow since the last delivery").Default("0s").IsSetByUser(&c.consumerLastDeliveryCriticalIsSet).DurationVar(&c.consumerLastDeliveryCritical)
 	consumer.Flag("last-ack-critical", "Time to allow since the last ack").Default("0s").IsSetByUser(&c.consumerLastAckCriticalIsSet).DurationVar(&c.consumerLastAckCritical)
+	consumer.Flag("redelivery-warn", "Warning threshold for the number of redeliveries").Default("-1").IsSetByUser(&c.consumerRedeliveryWarnIsSet).IntVar(&c.consumerRedeliveryWarn)
 	consumer.Flag("redelivery-critical", "Maximum number of redeliveries to allow").Default("-1").IsSetByUser(&c.consumerRedeliveryCriticalIsSet).IntVar(&c.consumerRedeliveryCritical)
+	consumer.Flag("backoff", "Expected backoff schedule, can be repeated").PlaceHolder("DURATION").DurationListVar(&c.consumerBackoff)
+	consumer.Flag("placement", "Warns when the consumer peers are not placed on the stream peers").UnNegatableBoolVar(&c.consumerPlacement)
+	consumer.Flag("paused", "Checks that the consumer is paused, --no-paused checks it is not paused").IsSetByUser(&c.consumerPausedIsSet).BoolVar(&c.consumerPaused)
+	consumer.Flag("pause-remaining-warn", "Warning threshold for the time remaining before a paused consumer resumes").PlaceHolder("DURATION").DurationVar(&c.consumerPauseRemainingWarn)
+	consumer.Flag("durable", "Checks that the consumer is durable").UnNegatableBoolVar(&c.consumerDurable)
+	consumer.Flag("filter-overlap", "Checks that the consumer filter subjects overlap the stream subjects").UnNegatableBoolVar(&c.consumerFilterOverlap)
+	consumer.Flag("expect-metadata", "Checks that the consumer metadata holds KEY=VALUE, can be repeated").PlaceHolder("KEY=VALUE").StringMapVar(&c.expectMetadata)
 	consumer.Flag("metadata", "Sets monitoring thresholds from Consumer metadata").Default("true").BoolVar(&c.useMetadata)
 
+	consumers := check.Command("consumers", "Checks the health of all consumers on a stream").Action(c.checkStreamConsumersAction)
+	consumers.HelpLong(`Every consumer on the stream is checked using the same thresholds as the consumer
+check and the result is the worst state of any consumer.`)
+	consumers.Flag("stream", "The streams to check").Required().StringVar(&c.sourcesStream)
+	consumers.Flag("outstanding-ack-warn", "Warning threshold for the number of outstanding acks").Default("-1").IsSetByUser(&c.consumerAckOutstandingWarnIsSet).IntVar(&c.consumerAckOutstandingWarn)
+	consumers.Flag("outstanding-ack-critical", "Maximum number of outstanding acks to allow").Default("-1").IsSetByUser(&c.consumerAckOutstandingCriticalIsSet).IntVar(&c.consumerAckOutstandingCritical)
+	consumers.Flag("ack-pending-warn", "Warning threshold for outstanding acks as a percentage of max ack pending").Default("-1").IsSetByUser(&c.consumerAckPendingWarnIsSet).IntVar(&c.consumerAckPendingWarn)
+	consumers.Flag("ack-pending-critical", "Critical threshold for outstanding acks as a percentage of max ack pending").Default("-1").IsSetByUser(&c.consumerAckPendingCritIsSet).IntVar(&c.consumerAckPendingCrit)
+	consumers.Flag("waiting-critical", "Maximum number of waiting pulls to allow").Default("-1").IsSetByUser(&c.consumerWaitingCriticalIsSet).IntVar(&c.consumerWaitingCritical)
+	consumers.Flag("unprocessed-warn", "Warning threshold for the number of unprocessed messages").Default("-1").IsSetByUser(&c.consumerUnprocessedWarnIsSet).IntVar(&c.consumerUnprocessedWarn)
+	consumers.Flag("unprocessed-critical", "Maximum number of unprocessed messages to allow").Default("-1").IsSetByUser(&c.consumerUnprocessedCriticalIsSet).IntVar(&c.consumerUnprocessedCritical)
+	consumers.Flag("last-delivery-critical", "Time to allow since the last delivery").Default("0s").IsSetByUser(&c.consumerLastDeliveryCriticalIsSet).DurationVar(&c.consumerLastDeliveryCritical)
+	consumers.Flag("last-ack-critical", "Time to allow since the last ack").Default("0s").IsSetByUser(&c.consumerLastAckCriticalIsSet).DurationVar(&c.consumerLastAckCritical)
+	consumers.Flag("redelivery-warn", "Warning threshold for the number of redeliveries").Default("-1").IsSetByUser(&c.consumerRedeliveryWarnIsSet).IntVar(&c.consumerRedeliveryWarn)
+	consumers.Flag("redelivery-critical", "Maximum number of redeliveries to allow").Default("-1").IsSetByUser(&c.consumerRedeliveryCriticalIsSet).IntVar(&c.consumerRedeliveryCritical)
+	consumers.Flag("metadata", "Sets monitoring thresholds from Consumer metadata").Default("true").BoolVar(&c.useMetadata)
+
 	msg := check.Command("message", "Checks properties of a message stored in a stream").Action(c.checkMsg)
 	msg.Flag("stream", "The streams to check").Required().StringVar(&c.sourcesStream)
 	msg.Flag("subject", "The subject to fetch a message from").Default(">").StringVar(&c.msgSubject)
@@ -187,6 +522,36 @@ When set these settings will be used, but can be overridden using --waiting-crit
 	msg.Flag("content", "Regular expression to check the content against").PlaceHolder("REGEX").RegexpVar(&c.msgRegexp)
 	msg.Flag("body-timestamp", "Use message body as a unix timestamp instead of message metadata").UnNegatableBoolVar(&c.msgBodyAsTs)
 
+	mapping := check.Command("mapping", "Checks the destination weights of a subject mapping").Action(c.checkMappingAction)
+	mapping.Flag("name", "Server name to require in the result").Required().StringVar(&c.srvName)
+	mapping.Flag("account", "The account holding the mapping").Required().StringVar(&c.mapAccount)
+	mapping.Flag("source", "The source subject of the mapping").Required().StringVar(&c.mapSource)
+	mapping.Flag("dest", "Expected destination and weight in SUBJECT=WEIGHT format, can be repeated").PlaceHolder("SUBJECT=WEIGHT").Required().StringMapVar(&c.mapDests)
+
+	acct := check.Command("account", "Checks account connections and subscriptions against the account limits").Action(c.checkAccountLimitsAction)
+	acct.HelpLong(`Connections are counted across all servers and compared with the account connection
+limit. Subscription limits apply to each connection so the busiest connection is
+compared with the account subscription limit.`)
+	acct.Flag("name", "Server name to retrieve the account limits from").Required().StringVar(&c.srvName)
+	acct.Flag("account", "The account to check").Required().StringVar(&c.acctName)
+	acct.Flag("connections-warn", "Warning threshold for connections as a percentage of the account limit").PlaceHolder("PERCENT").Default("-1").IntVar(&c.acctConnWarn)
+	acct.Flag("connections-critical", "Critical threshold for connections as a percentage of the account limit").PlaceHolder("PERCENT").Default("-1").IntVar(&c.acctConnCrit)
+	acct.Flag("subscriptions-warn", "Warning threshold for subscriptions on the busiest connection as a percentage of the account limit").PlaceHolder("PERCENT").Default("-1").IntVar(&c.acctSubsWarn)
+	acct.Flag("subscriptions-critical", "Critical threshold for subscriptions on the busiest connection as a percentage of the account limit").PlaceHolder("PERCENT").Default("-1").IntVar(&c.acctSubsCrit)
+
+	vis := check.Command("visibility", "Checks that JetStream Streams and KV Buckets are reachable").Action(c.checkVisibilityAction)
+	vis.HelpLong(`Connect using a leafnode or set --js-domain to verify the assets are reachable
+through the leafnode connection rather than only checking the link state.`)
+	vis.Flag("stream", "Stream that must be reachable, can be repeated").StringsVar(&c.visStreams)
+	vis.Flag("bucket", "KV Bucket that must be reachable, can be repeated").StringsVar(&c.visBuckets)
+
+	headers := check.Command("headers", "Checks that recent messages in a stream carry required headers").Action(c.checkHeadersAction)
+	headers.Flag("stream", "The streams to check").Required().StringVar(&c.sourcesStream)
+	headers.Flag("header", "Header that must be present on every message, can be repeated").Required().StringsVar(&c.hdrNames)
+	headers.Flag("count", "Number of recent messages to inspect").Default("10").IntVar(&c.hdrCount)
+	headers.Flag("missing-warn", "Warning threshold for messages missing headers, in percent").Default("-1").IntVar(&c.hdrMissingWarn)
+	headers.Flag("missing-critical", "Critical threshold for messages missing headers, in percent").Default("0").IntVar(&c.hdrMissingCrit)
+
 	meta := check.Command("meta", "Check JetStream cluster state").Alias("raft").Action(c.checkRaft)
 	meta.Flag("expect", "Number of servers to expect").Required().PlaceHolder("SERVERS").IntVar(&c.raftExpect)
 	meta.Flag("lag-critical", "Critical threshold to allow for lag").PlaceHolder("OPS").Required().Uint64Var(&c.raftLagCritical)
@@ -215,11 +580,18 @@ When set these settings will be used, but can be overridden using --waiting-crit
 	serv.Flag("conn-critical", "Critical threshold for connections, supports inversion").IntVar(&c.srvConnCrit)
 	serv.Flag("subs-warn", "Warning threshold for number of active subscriptions, supports inversion").IntVar(&c.srvSubsWarn)
 	serv.Flag("subs-critical", "Critical threshold for number of active subscriptions, supports inversion").IntVar(&c.srvSubCrit)
+	serv.Flag("websocket-tls", "Checks that the WebSocket listener requires TLS").UnNegatableBoolVar(&c.srvWSTLS)
+	serv.Flag("websocket-origin", "Origin the WebSocket listener should allow, can be repeated").PlaceHolder("ORIGIN").StringsVar(&c.srvWSOrigins)
+	serv.Flag("websocket-url", "WebSocket listener to perform handshakes against for --websocket-tls and --websocket-origin").PlaceHolder("URL").StringVar(&c.srvWSURL)
+	serv.Flag("mqtt-tls", "Checks that the MQTT listener on this address rejects plaintext connections").PlaceHolder("HOST:PORT").StringVar(&c.srvMQTTTLS)
 	serv.Flag("uptime-warn", "Warning threshold for server uptime as duration").DurationVar(&c.srvUptimeWarn)
 	serv.Flag("uptime-critical", "Critical threshold for server uptime as duration").DurationVar(&c.srvUptimeCrit)
 	serv.Flag("auth-required", "Checks that authentication is enabled").UnNegatableBoolVar(&c.srvAuthRequire)
 	serv.Flag("tls-required", "Checks that TLS is required").UnNegatableBoolVar(&c.srvTLSRequired)
 	serv.Flag("js-required", "Checks that JetStream is enabled").UnNegatableBoolVar(&c.srvJSRequired)
+	serv.Flag("jetstream-domain", "Checks that JetStream is enabled in a specific domain").PlaceHolder("DOMAIN").StringVar(&c.srvJSDomain)
+	serv.Flag("write-deadline", "Checks that the write deadline is set to this duration").PlaceHolder("DURATION").DurationVar(&c.srvWriteDeadline)
+	serv.Flag("max-pending", "Checks that the slow consumer pending size is set to this size").PlaceHolder("BYTES").BytesVar(&c.srvMaxPending)
 
 	kv := check.Command("kv", "Checks a NATS KV Bucket").Action(c.checkKV)
 	kv.Flag("bucket", "Checks a specific bucket").Required().StringVar(&c.kvBucket)
@@ -227,8 +599,58 @@ When set these settings will be used, but can be overridden using --waiting-crit
 	kv.Flag("values-warn", "Warning threshold for number of values in the bucket").Default("-1").IntVar(&c.kvValuesWarn)
 	kv.Flag("key", "Requires a key to have any non-delete value set").StringVar(&c.kvKey)
 
+	throughput := check.Command("throughput", "Checks the message and byte rates of an account").Action(c.checkThroughputAction)
+	throughput.HelpLong(`Rates are calculated by sampling the account statistics from all servers twice,
+--interval apart, and are expressed per second.`)
+	throughput.Flag("account", "The account to check").Required().StringVar(&c.acctName)
+	throughput.Flag("interval", "Time to wait between samples").Default("5s").PlaceHolder("DURATION").DurationVar(&c.throughputInterval)
+	throughput.Flag("in-msgs-warn", "Warning threshold for messages received from clients per second").Default("-1").IntVar(&c.throughputInMsgsWarn)
+	throughput.Flag("in-msgs-critical", "Critical threshold for messages received from clients per second").Default("-1").IntVar(&c.throughputInMsgsCrit)
+	throughput.Flag("in-bytes-warn", "Warning threshold for bytes received from clients per second").PlaceHolder("BYTES").Default("-1B").BytesVar(&c.throughputInBytesWarn)
+	throughput.Flag("in-bytes-critical", "Critical threshold for bytes received from clients per second").PlaceHolder("BYTES").Default("-1B").BytesVar(&c.throughputInBytesCrit)
+	throughput.Flag("out-msgs-warn", "Warning threshold for messages sent to clients per second").Default("-1").IntVar(&c.throughputOutMsgsWarn)
+	throughput.Flag("out-msgs-critical", "Critical threshold for messages sent to clients per second").Default("-1").IntVar(&c.throughputOutMsgsCrit)
+	throughput.Flag("out-bytes-warn", "Warning threshold for bytes sent to clients per second").PlaceHolder("BYTES").Default("-1B").BytesVar(&c.throughputOutBytesWarn)
+	throughput.Flag("out-bytes-critical", "Critical threshold for bytes sent to clients per second").PlaceHolder("BYTES").Default("-1B").BytesVar(&c.throughputOutBytesCrit)
+
+	msgRate := check.Command("messagerate", "Checks the rate at which messages are added to a stream").Action(c.checkMessageRateAction)
+	msgRate.HelpLong(`The last sequence of the stream is sampled twice, --interval apart, and the
+difference is expressed as messages per second.  Use the --min thresholds to detect
+stuck publishers and the --max thresholds to detect unexpected spikes.`)
+	msgRate.Flag("stream", "The stream to check").Required().StringVar(&c.sourcesStream)
+	msgRate.Flag("interval", "Time to wait between samples").Default("5s").PlaceHolder("DURATION").DurationVar(&c.msgRateInterval)
+	msgRate.Flag("min-warn", "Warn if fewer than this many messages per second are added").Default("-1").IntVar(&c.msgRateMinWarn)
+	msgRate.Flag("min-critical", "Critical if fewer than this many messages per second are added").Default("-1").IntVar(&c.msgRateMinCrit)
+	msgRate.Flag("max-warn", "Warn if more than this many messages per second are added").Default("-1").IntVar(&c.msgRateMaxWarn)
+	msgRate.Flag("max-critical", "Critical if more than this many messages per second are added").Default("-1").IntVar(&c.msgRateMaxCrit)
+
+	growth := check.Command("connection-growth", "Checks for steadily growing connection counts in an account").Action(c.checkConnectionGrowthAction)
+	growth.HelpLong(`The account statistics from all servers are sampled --samples times, --interval apart.
+When the connection count never drops and grows by more than the thresholds over the
+window clients are likely leaking connections.`)
+	growth.Flag("account", "The account to check").Required().StringVar(&c.acctName)
+	growth.Flag("samples", "Number of samples to take").Default("5").IntVar(&c.connGrowthSamples)
+	growth.Flag("interval", "Time to wait between samples").Default("5s").PlaceHolder("DURATION").DurationVar(&c.connGrowthInterval)
+	growth.Flag("growth-warn", "Warning threshold for connections gained over the sample window").Default("-1").IntVar(&c.connGrowthWarn)
+	growth.Flag("growth-critical", "Critical threshold for connections gained over the sample window").Default("-1").IntVar(&c.connGrowthCrit)
+
+	assets := check.Command("assets", "Checks the number of JetStream assets hosted on a server").Action(c.checkAssetsAction)
+	assets.HelpLong(`Every Stream and Consumer on a file storage node holds open files, very large numbers
+of assets can exhaust the file descriptor limits of the server. The server does not
+report its operating system limits so thresholds should be set based on the limits
+configured for the server process.`)
+	assets.Flag("name", "Server name to require in the result").Required().StringVar(&c.srvName)
+	assets.Flag("assets-warn", "Warning threshold for the number of Streams and Consumers on the server").Default("-1").IntVar(&c.assetsWarn)
+	assets.Flag("assets-critical", "Critical threshold for the number of Streams and Consumers on the server").Default("-1").IntVar(&c.assetsCrit)
+
+	naming := check.Command("naming", "Checks that Stream and Consumer names follow a naming convention").Action(c.checkNamingAction)
+	naming.Flag("stream", "Regular expression Stream names should match").PlaceHolder("REGEX").RegexpVar(&c.namingStream)
+	naming.Flag("consumer", "Regular expression Consumer names should match").PlaceHolder("REGEX").RegexpVar(&c.namingConsumer)
+	naming.Flag("violations-warn", "Warning threshold for number of non-conforming names").Default("-1").IntVar(&c.namingViolationsWarn)
+	naming.Flag("violations-critical", "Critical threshold for number of non-conforming names").Default("1").IntVar(&c.namingViolationsCrit)
+
 	cred := check.Command("credential", "Checks the validity of a NATS credential file").Action(c.checkCredentialAction)
-	cred.Flag("credential", "The file holding the NATS credential").Required().StringVar(&c.credential)
+	cred.Flag("credential", "The file holding the NATS credential, environment variables like $NATS_CREDS_FILE are expanded").Required().StringVar(&c.credential)
 	cred.Flag("validity-warn", "Warning threshold for time before expiry").DurationVar(&c.credentialValidityWarn)
 	cred.Flag("validity-critical", "Critical threshold for time before expiry").DurationVar(&c.credentialValidityCrit)
 	cred.Flag("require-expiry", "Requires the credential to have expiry set").Default("true").BoolVar(&c.credentialRequiresExpire)
@@ -323,10 +745,10 @@ func (c *SrvCheckCmd) checkKVStatusAndBucket(check *monitor.Result, nc *nats.Con
 }
 
 func (c *SrvCheckCmd) checkConsumerStatus(check *monitor.Result, nfo api.ConsumerInfo) {
-	check.Pd(&monitor.PerfDataItem{Name: "ack_pending", Value: float64(nfo.NumAckPending), Help: "The number of messages waiting to be Acknowledged", Crit: float64(c.consumerAckOutstandingCritical)})
+	check.Pd(&monitor.PerfDataItem{Name: "ack_pending", Value: float64(nfo.NumAckPending), Help: "The number of messages waiting to be Acknowledged", Warn: float64(c.consumerAckOutstandingWarn), Crit: float64(c.consumerAckOutstandingCritical)})
 	check.Pd(&monitor.PerfDataItem{Name: "pull_waiting", Value: float64(nfo.NumWaiting), Help: "The number of waiting Pull requests", Crit: float64(c.consumerWaitingCritical)})
-	check.Pd(&monitor.PerfDataItem{Name: "pending", Value: float64(nfo.NumPending), Help: "The number of messages that have not yet been consumed", Crit: float64(c.consumerUnprocessedCritical)})
-	check.Pd(&monitor.PerfDataItem{Name: "redelivered", Value: float64(nfo.NumRedelivered), Help: "The number of messages currently being redelivered", Crit: float64(c.consumerRedeliveryCritical)})
+	check.Pd(&monitor.PerfDataItem{Name: "pending", Value: float64(nfo.NumPending), Help: "The number of messages that have not yet been consumed", Warn: float64(c.consumerUnprocessedWarn), Crit: float64(c.consumerUnprocessedCritical)})
+	check.Pd(&monitor.PerfDataItem{Name: "redelivered", Value: float64(nfo.NumRedelivered), Help: "The number of messages currently being redelivered", Warn: float64(c.consumerRedeliveryWarn), Crit: float64(c.consumerRedeliveryCritical)})
 	if nfo.Delivered.Last != nil {
 		check.Pd(&monitor.PerfDataItem{Name: "last_delivery", Value: time.Since(*nfo.Delivered.Last).Seconds(), Unit: "s", Help: "Seconds since the last message was delivered", Crit: c.consumerLastDeliveryCritical.Seconds()})
 	}
@@ -334,20 +756,41 @@ func (c *SrvCheckCmd) checkConsumerStatus(check *monitor.Result, nfo api.Consume
 		check.Pd(&monitor.PerfDataItem{Name: "last_ack", Value: time.Since(*nfo.AckFloor.Last).Seconds(), Unit: "s", Help: "Seconds since the last message was acknowledged", Crit: c.consumerLastDeliveryCritical.Seconds()})
 	}
 
-	if c.consumerAckOutstandingCritical > 0 && nfo.NumAckPending >= c.consumerAckOutstandingCritical {
+	switch {
+	case c.consumerAckOutstandingCritical > 0 && nfo.NumAckPending >= c.consumerAckOutstandingCritical:
 		check.Critical("Ack Pending: %d", nfo.NumAckPending)
+	case c.consumerAckOutstandingWarn > 0 && nfo.NumAckPending >= c.consumerAckOutstandingWarn:
+		check.Warn("Ack Pending: %d", nfo.NumAckPending)
+	}
+
+	if nfo.Config.MaxAckPending > 0 {
+		pct := float64(nfo.NumAckPending) * 100 / float64(nfo.Config.MaxAckPending)
+		check.Pd(&monitor.PerfDataItem{Name: "ack_pending_pct", Value: pct, Unit: "%", Warn: float64(c.consumerAckPendingWarn), Crit: float64(c.consumerAckPendingCrit), Help: "Outstanding acks as a percentage of max ack pending"})
+
+		switch {
+		case c.consumerAckPendingCrit > 0 && pct >= float64(c.consumerAckPendingCrit):
+			check.Critical("Ack Pending: %.0f%% of %d", pct, nfo.Config.MaxAckPending)
+		case c.consumerAckPendingWarn > 0 && pct >= float64(c.consumerAckPendingWarn):
+			check.Warn("Ack Pending: %.0f%% of %d", pct, nfo.Config.MaxAckPending)
+		}
 	}
 
 	if c.consumerWaitingCritical > 0 && nfo.NumWaiting >= c.consumerWaitingCritical {
 		check.Critical("Waiting Pulls: %d", nfo.NumWaiting)
 	}
 
-	if c.consumerUnprocessedCritical > 0 && nfo.NumPending >= uint64(c.consumerUnprocessedCritical) {
+	switch {
+	case c.consumerUnprocessedCritical > 0 && nfo.NumPending >= uint64(c.consumerUnprocessedCritical):
 		check.Critical("Unprocessed Messages: %d", nfo.NumPending)
+	case c.consumerUnprocessedWarn > 0 && nfo.NumPending >= uint64(c.consumerUnprocessedWarn):
+		check.Warn("Unprocessed Messages: %d", nfo.NumPending)
 	}
 
-	if c.consumerRedeliveryCritical > 0 && nfo.NumRedelivered > c.consumerRedeliveryCritical {
+	switch {
+	case c.consumerRedeliveryCritical > 0 && nfo.NumRedelivered > c.consumerRedeliveryCritical:
 		check.Critical("Redelivered Messages: %d", nfo.NumRedelivered)
+	case c.consumerRedeliveryWarn > 0 && nfo.NumRedelivered > c.consumerRedeliveryWarn:
+		check.Warn("Redelivered Messages: %d", nfo.NumRedelivered)
 	}
 
 	switch {
@@ -374,6 +817,12 @@ func (c *SrvCheckCmd) checkConsumer(_ *fisk.ParseContext) error {
 	_, mgr, err := prepareHelper("", natsOpts()...)
 	check.CriticalIfErr(err, "connection failed: %s", err)
 
+	if c.consumerExists {
+		err = c.checkConsumerExists(check, mgr)
+		check.CriticalIfErr(err, "consumer lookup failure: %v", err)
+		return nil
+	}
+
 	cons, err := mgr.LoadConsumer(c.sourcesStream, c.consumerName)
 	if err != nil {
 		check.Critical("consumer load failure: %v", err)
@@ -395,9 +844,253 @@ func (c *SrvCheckCmd) checkConsumer(_ *fisk.ParseContext) error {
 
 	c.checkConsumerStatus(check, nfo)
 
+	if len(c.consumerBackoff) > 0 {
+		c.checkConsumerBackoff(check, nfo)
+	}
+
+	if c.consumerPausedIsSet {
+		c.checkConsumerPaused(check, nfo)
+	}
+
+	if c.consumerDurable {
+		c.checkConsumerDurable(check, nfo)
+	}
+
+	if len(c.expectMetadata) > 0 {
+		c.checkMetadata(check, nfo.Config.Metadata)
+	}
+
+	if c.consumerPlacement || c.consumerFilterOverlap {
+		stream, err := mgr.LoadStream(c.sourcesStream)
+		check.CriticalIfErr(err, "could not load stream %s: %s", c.sourcesStream, err)
+
+		sinfo, err := stream.LatestInformation()
+		check.CriticalIfErr(err, "could not load stream %s info: %s", c.sourcesStream, err)
+
+		if c.consumerPlacement {
+			c.checkConsumerPlacement(check, sinfo.Cluster, nfo.Cluster)
+		}
+
+		if c.consumerFilterOverlap {
+			c.checkConsumerFilterOverlap(check, &sinfo.Config, &nfo.Config)
+		}
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkConsumerPlacement(check *monitor.Result, stream *api.ClusterInfo, consumer *api.ClusterInfo) {
+	peers := func(ci *api.ClusterInfo) []string {
+		if ci == nil || ci.Leader == "" {
+			return nil
+		}
+
+		res := []string{ci.Leader}
+		for _, r := range ci.Replicas {
+			res = append(res, r.Name)
+		}
+
+		return res
+	}
+
+	streamPeers := peers(stream)
+	consumerPeers := peers(consumer)
+
+	if len(streamPeers) == 0 || len(consumerPeers) == 0 {
+		check.Critical("no cluster information")
+		return
+	}
+
+	overlap := 0
+	var misplaced []string
+	for _, p := range consumerPeers {
+		if slices.Contains(streamPeers, p) {
+			overlap++
+		} else {
+			misplaced = append(misplaced, p)
+		}
+	}
+
+	check.Pd(&monitor.PerfDataItem{Name: "peer_overlap", Value: float64(overlap), Help: "Consumer peers that are also peers of the stream"})
+
+	if len(misplaced) > 0 {
+		check.Warn("%d consumer peers not on stream peers: %s", len(misplaced), strings.Join(misplaced, ", "))
+		return
+	}
+
+	check.Ok("%d consumer peers placed with the stream", overlap)
+}
+
+func (c *SrvCheckCmd) checkStreamConsumers(check *monitor.Result, infos []api.ConsumerInfo, missing []string) error {
+	var critical, warning []string
+
+	for _, nfo := range infos {
+		// metadata thresholds are per consumer so each is checked using its own copy of the settings
+		cc := *c
+		if c.useMetadata {
+			err := cc.optionsFromConsumerMetadata(&nfo.Config)
+			if err != nil {
+				return err
+			}
+		}
+
+		res := &monitor.Result{}
+		cc.checkConsumerStatus(res, nfo)
+
+		switch {
+		case len(res.Criticals) > 0:
+			critical = append(critical, nfo.Name)
+		case len(res.Warnings) > 0:
+			warning = append(warning, nfo.Name)
+		}
+	}
+
+	critical = append(critical, missing...)
+	total := len(infos) + len(missing)
+	healthy := total - len(critical) - len(warning)
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "consumers", Value: float64(total), Help: "Number of consumers on the stream"},
+		&monitor.PerfDataItem{Name: "consumers_healthy", Value: float64(healthy), Help: "Number of healthy consumers on the stream"},
+	)
+
+	if len(critical) > 0 {
+		check.Critical("%d unhealthy consumers: %s", len(critical), strings.Join(critical, ", "))
+	}
+	if len(warning) > 0 {
+		check.Warn("%d degraded consumers: %s", len(warning), strings.Join(warning, ", "))
+	}
+	if len(critical) == 0 && len(warning) == 0 {
+		check.Ok("%d healthy consumers", healthy)
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkStreamConsumersAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.sourcesStream, Check: "consumers", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	consumers, missing, err := mgr.Consumers(c.sourcesStream)
+	check.CriticalIfErr(err, "could not load consumers for stream %s: %s", c.sourcesStream, err)
+
+	var infos []api.ConsumerInfo
+	for _, cons := range consumers {
+		nfo, err := cons.LatestState()
+		if err != nil {
+			missing = append(missing, cons.Name())
+			continue
+		}
+		infos = append(infos, nfo)
+	}
+
+	err = c.checkStreamConsumers(check, infos, missing)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkConsumerFilterOverlap(check *monitor.Result, scfg *api.StreamConfig, ccfg *api.ConsumerConfig) {
+	filters := ccfg.FilterSubjects
+	if ccfg.FilterSubject != "" {
+		filters = append([]string{ccfg.FilterSubject}, filters...)
+	}
+
+	if len(filters) == 0 {
+		check.Ok("No filter subjects")
+		return
+	}
+
+	if len(scfg.Subjects) == 0 {
+		check.Ok("Stream has no subjects, filter overlap not checked")
+		return
+	}
+
+	var unmatched []string
+	for _, filter := range filters {
+		if !slices.ContainsFunc(scfg.Subjects, func(subj string) bool { return server.SubjectsCollide(filter, subj) }) {
+			unmatched = append(unmatched, filter)
+		}
+	}
+
+	if len(unmatched) > 0 {
+		check.Critical("Filter subjects do not overlap the stream subjects: %s", strings.Join(unmatched, ", "))
+		return
+	}
+
+	check.Ok("Filter subjects overlap the stream subjects")
+}
+
+func (c *SrvCheckCmd) checkConsumerExists(check *monitor.Result, mgr *jsm.Manager) error {
+	known, err := mgr.IsKnownConsumer(c.sourcesStream, c.consumerName)
+	if err != nil {
+		return err
+	}
+
+	if !known {
+		check.Critical("consumer %s not found on stream %s", c.consumerName, c.sourcesStream)
+		return nil
+	}
+
+	check.Ok("consumer %s exists on stream %s", c.consumerName, c.sourcesStream)
+
 	return nil
 }
 
+func (c *SrvCheckCmd) checkConsumerDurable(check *monitor.Result, nfo api.ConsumerInfo) {
+	if nfo.Config.Durable == "" {
+		check.Critical("Ephemeral consumer")
+		return
+	}
+
+	check.Ok("Durable consumer")
+}
+
+func (c *SrvCheckCmd) checkConsumerPaused(check *monitor.Result, nfo api.ConsumerInfo) {
+	check.Pd(&monitor.PerfDataItem{Name: "pause_remaining", Value: nfo.PauseRemaining.Seconds(), Warn: c.consumerPauseRemainingWarn.Seconds(), Unit: "s", Help: "Time remaining before the paused consumer resumes"})
+
+	switch {
+	case nfo.Paused && !c.consumerPaused:
+		check.Critical("Paused until %s", nfo.Config.PauseUntil.Format(time.RFC3339))
+	case !nfo.Paused && c.consumerPaused:
+		check.Critical("Not paused")
+	case nfo.Paused && c.consumerPauseRemainingWarn > 0 && nfo.PauseRemaining <= c.consumerPauseRemainingWarn:
+		check.Warn("Resuming in %s", nfo.PauseRemaining.Round(time.Second))
+	case nfo.Paused:
+		check.Ok("Paused until %s", nfo.Config.PauseUntil.Format(time.RFC3339))
+	default:
+		check.Ok("Not paused")
+	}
+}
+
+func (c *SrvCheckCmd) checkConsumerBackoff(check *monitor.Result, nfo api.ConsumerInfo) {
+	durationsString := func(d []time.Duration) string {
+		if len(d) == 0 {
+			return "none"
+		}
+
+		var res []string
+		for _, v := range d {
+			res = append(res, v.String())
+		}
+
+		return strings.Join(res, ", ")
+	}
+
+	observed := durationsString(nfo.Config.BackOff)
+	expected := durationsString(c.consumerBackoff)
+
+	if observed != expected {
+		check.Critical("Backoff %s expected %s", observed, expected)
+		return
+	}
+
+	check.Ok("Backoff %s", observed)
+}
+
 func (c *SrvCheckCmd) checkKV(_ *fisk.ParseContext) error {
 	check := &monitor.Result{Name: c.kvBucket, Check: "kv", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
 	defer check.GenericExit()
@@ -420,6 +1113,24 @@ func (c *SrvCheckCmd) checkSrv(_ *fisk.ParseContext) error {
 	err = c.checkVarz(check, vz)
 	check.CriticalIfErr(err, "check failed: %s", err)
 
+	if c.srvWSURL != "" {
+		o := nats.GetDefaultOptions()
+		for _, opt := range natsOpts() {
+			err = opt(&o)
+			check.CriticalIfErr(err, "invalid connection options: %s", err)
+		}
+
+		roots, err := c.tlsRoots(&o, nil)
+		check.CriticalIfErr(err, "could not load CA: %s", err)
+
+		err = c.probeWebsocket(check, c.srvWSURL, &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots})
+		check.CriticalIfErr(err, "WebSocket probe failed: %s", err)
+	}
+
+	if c.srvMQTTTLS != "" {
+		c.probeMQTTPlaintext(check, c.srvMQTTTLS)
+	}
+
 	return nil
 }
 
@@ -440,6 +1151,17 @@ func (c *SrvCheckCmd) checkVarz(check *monitor.Result, vz *server.Varz) error {
 		}
 	}
 
+	if c.srvJSDomain != "" {
+		switch {
+		case vz.JetStream.Config == nil:
+			check.Critical("JetStream not enabled, expected domain %s", c.srvJSDomain)
+		case vz.JetStream.Config.Domain != c.srvJSDomain:
+			check.Critical("JetStream domain %q expected %q", vz.JetStream.Config.Domain, c.srvJSDomain)
+		default:
+			check.Ok("JetStream domain %s", vz.JetStream.Config.Domain)
+		}
+	}
+
 	if c.srvTLSRequired {
 		if vz.TLSRequired {
 			check.Ok("TLS required")
@@ -456,6 +1178,26 @@ func (c *SrvCheckCmd) checkVarz(check *monitor.Result, vz *server.Varz) error {
 		}
 	}
 
+	if c.srvWriteDeadline > 0 {
+		if vz.WriteDeadline == c.srvWriteDeadline {
+			check.Ok("Write deadline %v", vz.WriteDeadline)
+		} else {
+			check.Critical("Write deadline %v expected %v", vz.WriteDeadline, c.srvWriteDeadline)
+		}
+	}
+
+	if c.srvMaxPending > 0 {
+		if vz.MaxPending == int64(c.srvMaxPending) {
+			check.Ok("Max pending %s", humanize.IBytes(uint64(vz.MaxPending)))
+		} else {
+			check.Critical("Max pending %s expected %s", humanize.IBytes(uint64(vz.MaxPending)), humanize.IBytes(uint64(c.srvMaxPending)))
+		}
+	}
+
+	if c.srvWSTLS || len(c.srvWSOrigins) > 0 {
+		c.checkWebsocketVarz(check, &vz.Websocket)
+	}
+
 	up := vz.Now.Sub(vz.Start)
 	if c.srvUptimeWarn > 0 || c.srvUptimeCrit > 0 {
 		if c.srvUptimeCrit > c.srvUptimeWarn {
@@ -517,50 +1259,173 @@ func (c *SrvCheckCmd) checkVarz(check *monitor.Result, vz *server.Varz) error {
 	return nil
 }
 
-func (c *SrvCheckCmd) fetchVarz() (*server.Varz, error) {
-	var vz json.RawMessage
+func (c *SrvCheckCmd) checkWebsocketVarz(check *monitor.Result, ws *server.WebsocketOptsVarz) {
+	if ws.Port == 0 {
+		check.Critical("WebSocket not enabled")
+		return
+	}
 
-	if c.srvURL == nil {
-		nc, _, err := prepareHelper("", natsOpts()...)
-		if err != nil {
-			return nil, err
+	if c.srvWSTLS {
+		if ws.NoTLS {
+			check.Critical("WebSocket TLS not required")
+		} else {
+			check.Ok("WebSocket TLS required")
 		}
+	}
 
-		if c.srvName == "" {
-			return nil, fmt.Errorf("server name is required")
+	if len(c.srvWSOrigins) > 0 {
+		originsString := func(o []string) string {
+			if len(o) == 0 {
+				return "any"
+			}
+
+			sorted := slices.Clone(o)
+			slices.Sort(sorted)
+
+			return strings.Join(sorted, ", ")
 		}
 
-		res, err := doReq(server.VarzEventOptions{EventFilterOptions: server.EventFilterOptions{Name: c.srvName}}, "$SYS.REQ.SERVER.PING.VARZ", 1, nc)
-		if err != nil {
-			return nil, err
+		observed := originsString(ws.AllowedOrigins)
+		expected := originsString(c.srvWSOrigins)
+
+		if observed != expected {
+			check.Critical("WebSocket allowed origins %s expected %s", observed, expected)
+		} else {
+			check.Ok("WebSocket allowed origins %s", observed)
 		}
+	}
+}
+
+// websocketProbeOrigin is sent to verify that origins outside --websocket-origin are rejected
+const websocketProbeOrigin = "https://natscli.invalid"
+
+// probeWebsocket performs handshakes against the WebSocket listener at target to
+// verify that the TLS and origin policies reported in VARZ are enforced
+func (c *SrvCheckCmd) probeWebsocket(check *monitor.Result, target string, tlsc *tls.Config) error {
+	u, err := url.Parse(target)
+	if err != nil {
+		return err
+	}
+
+	switch u.Scheme {
+	case "ws":
+		u.Scheme = "http"
+	case "wss":
+		u.Scheme = "https"
+	default:
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
 
-		if len(res) != 1 {
-			return nil, fmt.Errorf("received %d responses for %s", len(res), c.srvName)
+	if c.srvWSTLS {
+		plain := *u
+		plain.Scheme = "http"
+
+		status, accepted, err := c.websocketHandshake(&plain, nil, "")
+		switch {
+		case accepted:
+			check.Critical("WebSocket accepted a plaintext handshake")
+		case err != nil:
+			check.Ok("WebSocket rejected a plaintext handshake: %s", err)
+		default:
+			check.Ok("WebSocket rejected a plaintext handshake with %s", status)
 		}
+	}
 
-		reqresp := map[string]json.RawMessage{}
-		err = json.Unmarshal(res[0], &reqresp)
-		if err != nil {
-			return nil, err
+	if len(c.srvWSOrigins) > 0 {
+		for _, origin := range c.srvWSOrigins {
+			status, accepted, err := c.websocketHandshake(u, tlsc, origin)
+			switch {
+			case err != nil:
+				check.Critical("WebSocket handshake with origin %s failed: %s", origin, err)
+			case !accepted:
+				check.Critical("WebSocket rejected origin %s with %s", origin, status)
+			default:
+				check.Ok("WebSocket accepted origin %s", origin)
+			}
 		}
 
-		errresp, ok := reqresp["error"]
-		if ok {
-			return nil, fmt.Errorf("invalid response received: %#v", errresp)
+		status, accepted, err := c.websocketHandshake(u, tlsc, websocketProbeOrigin)
+		switch {
+		case err != nil:
+			check.Critical("WebSocket handshake with origin %s failed: %s", websocketProbeOrigin, err)
+		case accepted:
+			check.Critical("WebSocket accepted disallowed origin %s", websocketProbeOrigin)
+		default:
+			check.Ok("WebSocket rejected disallowed origin %s with %s", websocketProbeOrigin, status)
 		}
+	}
 
-		vz = reqresp["data"]
-	} else {
-		return nil, fmt.Errorf("not implemented")
+	return nil
+}
+
+// websocketHandshake requests a WebSocket upgrade from u, the handshake is
+// accepted when the server switches protocols
+func (c *SrvCheckCmd) websocketHandshake(u *url.URL, tlsc *tls.Config, origin string) (string, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", false, err
+	}
+
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", base64.StdEncoding.EncodeToString([]byte(nuid.Next()[:16])))
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+
+	client := &http.Client{
+		Timeout:   opts().Timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsc},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	resp.Body.Close()
+
+	return resp.Status, resp.StatusCode == http.StatusSwitchingProtocols, nil
+}
+
+// probeMQTTPlaintext sends a plaintext MQTT CONNECT to address, a listener
+// requiring TLS fails the handshake rather than answering with a CONNACK
+func (c *SrvCheckCmd) probeMQTTPlaintext(check *monitor.Result, address string) {
+	conn, err := net.DialTimeout("tcp", address, opts().Timeout)
+	if err != nil {
+		check.Critical("MQTT connection failed: %s", err)
+		return
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(opts().Timeout))
+
+	// CONNECT using protocol level 4 with a clean session and client id natscli
+	connect := []byte{0x10, 19, 0, 4, 'M', 'Q', 'T', 'T', 4, 0x02, 0, 60, 0, 7, 'n', 'a', 't', 's', 'c', 'l', 'i'}
+
+	hdr := make([]byte, 1)
+	_, err = conn.Write(connect)
+	if err == nil {
+		_, err = io.ReadFull(conn, hdr)
+	}
+
+	switch {
+	case err != nil:
+		check.Ok("MQTT rejected a plaintext connection: %s", err)
+	case hdr[0] == 0x20:
+		check.Critical("MQTT accepted a plaintext connection")
+	default:
+		check.Ok("MQTT rejected a plaintext connection")
 	}
+}
 
-	if len(vz) == 0 {
-		return nil, fmt.Errorf("no data received for %s", c.srvName)
+func (c *SrvCheckCmd) fetchVarz() (*server.Varz, error) {
+	if c.srvURL != nil {
+		return nil, fmt.Errorf("not implemented")
 	}
 
 	varz := &server.Varz{}
-	err := json.Unmarshal(vz, varz)
+	err := c.fetchServerData("$SYS.REQ.SERVER.PING.VARZ", server.VarzEventOptions{EventFilterOptions: server.EventFilterOptions{Name: c.srvName}}, varz)
 	if err != nil {
 		return nil, err
 	}
@@ -568,6 +1433,45 @@ func (c *SrvCheckCmd) fetchVarz() (*server.Varz, error) {
 	return varz, nil
 }
 
+// fetchServerData performs a system request against the server named by --name and unmarshals the data into target
+func (c *SrvCheckCmd) fetchServerData(subj string, req any, target any) error {
+	nc, _, err := prepareHelper("", natsOpts()...)
+	if err != nil {
+		return err
+	}
+
+	if c.srvName == "" {
+		return fmt.Errorf("server name is required")
+	}
+
+	res, err := doReq(req, subj, 1, nc)
+	if err != nil {
+		return err
+	}
+
+	if len(res) != 1 {
+		return fmt.Errorf("received %d responses for %s", len(res), c.srvName)
+	}
+
+	reqresp := map[string]json.RawMessage{}
+	err = json.Unmarshal(res[0], &reqresp)
+	if err != nil {
+		return err
+	}
+
+	errresp, ok := reqresp["error"]
+	if ok {
+		return fmt.Errorf("invalid response received: %#v", errresp)
+	}
+
+	data := reqresp["data"]
+	if len(data) == 0 {
+		return fmt.Errorf("no data received for %s", c.srvName)
+	}
+
+	return json.Unmarshal(data, target)
+}
+
 func (c *SrvCheckCmd) checkJS(_ *fisk.ParseContext) error {
 	check := &monitor.Result{Name: "JetStream", Check: "jetstream", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
 	defer check.GenericExit()
@@ -733,11 +1637,41 @@ func (c *SrvCheckCmd) checkAccountInfo(check *monitor.Result, info *api.JetStrea
 	checkVal("streams", "", c.jsStreamsWarn, c.jsStreamsCritical, int64(info.Limits.MaxStreams), uint64(info.Streams))
 	checkVal("consumers", "", c.jsConsumersWarn, c.jsConsumersCritical, int64(info.Limits.MaxConsumers), uint64(info.Consumers))
 
+	pct := func(max int64, current uint64) float64 {
+		if max <= 0 {
+			return 0
+		}
+
+		return float64(int(float64(current) / float64(max) * 100))
+	}
+
+	// thresholds apply to the account totals, tiers are reported to show which tier approaches its limits
+	var tiers []string
+	for name := range info.Tiers {
+		tiers = append(tiers, name)
+	}
+	slices.Sort(tiers)
+
+	for _, name := range tiers {
+		tier := info.Tiers[name]
+
+		check.Pd(
+			&monitor.PerfDataItem{Name: "tier_memory", Item: name, Value: float64(tier.Memory), Unit: "B", Help: "JetStream memory usage per tier"},
+			&monitor.PerfDataItem{Name: "tier_memory_pct", Item: name, Value: pct(tier.Limits.MaxMemory, tier.Memory), Unit: "%", Help: "JetStream memory usage per tier in percent"},
+			&monitor.PerfDataItem{Name: "tier_reserved_memory", Item: name, Value: float64(tier.ReservedMemory), Unit: "B", Help: "JetStream memory reserved per tier"},
+			&monitor.PerfDataItem{Name: "tier_storage", Item: name, Value: float64(tier.Store), Unit: "B", Help: "JetStream storage usage per tier"},
+			&monitor.PerfDataItem{Name: "tier_storage_pct", Item: name, Value: pct(tier.Limits.MaxStore, tier.Store), Unit: "%", Help: "JetStream storage usage per tier in percent"},
+			&monitor.PerfDataItem{Name: "tier_reserved_storage", Item: name, Value: float64(tier.ReservedStore), Unit: "B", Help: "JetStream storage reserved per tier"},
+			&monitor.PerfDataItem{Name: "tier_streams", Item: name, Value: float64(tier.Streams), Help: "JetStream streams per tier"},
+			&monitor.PerfDataItem{Name: "tier_consumers", Item: name, Value: float64(tier.Consumers), Help: "JetStream consumers per tier"},
+		)
+	}
+
 	return nil
 }
 
 func (c *SrvCheckCmd) checkRaft(_ *fisk.ParseContext) error {
-	check := &monitor.Result{Name: "JetStream Meta Cluster", Check: "meta"}
+	check := &monitor.Result{Name: "JetStream Meta Cluster", Check: "meta", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
 	defer check.GenericExit()
 
 	nc, _, err := prepareHelper("", natsOpts()...)
@@ -794,12 +1728,35 @@ func (c *SrvCheckCmd) checkRaft(_ *fisk.ParseContext) error {
 }
 
 func (c *SrvCheckCmd) checkMetaClusterInfo(check *monitor.Result, ci *server.MetaClusterInfo) error {
-	return c.checkClusterInfo(check, &server.ClusterInfo{
+	err := c.checkClusterInfo(check, &server.ClusterInfo{
 		Name:     ci.Name,
 		Leader:   ci.Leader,
 		Replicas: ci.Replicas,
 	})
-}
+	if err != nil {
+		return err
+	}
+
+	// per peer data lets the offending peer be identified without consulting the server
+	for _, peer := range ci.Replicas {
+		var current, offline float64
+		if peer.Current {
+			current = 1
+		}
+		if peer.Offline {
+			offline = 1
+		}
+
+		check.Pd(
+			&monitor.PerfDataItem{Name: "replica_current", Item: peer.Name, Value: current, Help: "Indicates if the RAFT peer is current"},
+			&monitor.PerfDataItem{Name: "replica_offline", Item: peer.Name, Value: offline, Help: "Indicates if the RAFT peer is offline"},
+			&monitor.PerfDataItem{Name: "replica_lag", Item: peer.Name, Value: float64(peer.Lag), Help: "Operations the RAFT peer is behind the leader"},
+			&monitor.PerfDataItem{Name: "replica_active", Item: peer.Name, Value: peer.Active.Seconds(), Unit: "s", Help: "Time since the RAFT peer was last seen"},
+		)
+	}
+
+	return nil
+}
 
 func (c *SrvCheckCmd) checkClusterInfo(check *monitor.Result, ci *server.ClusterInfo) error {
 	if ci == nil {
@@ -866,6 +1823,35 @@ func (c *SrvCheckCmd) checkClusterInfo(check *monitor.Result, ci *server.Cluster
 	return nil
 }
 
+func (c *SrvCheckCmd) checkPeerLagSpread(check *monitor.Result, ci *server.ClusterInfo) {
+	if ci == nil || ci.Leader == "" {
+		return
+	}
+
+	// the leader is the most advanced peer with no lag, so the spread is the lag of the least advanced replica
+	var spread uint64
+	for _, peer := range ci.Replicas {
+		if peer.Lag > spread {
+			spread = peer.Lag
+		}
+	}
+
+	check.Pd(&monitor.PerfDataItem{
+		Name:  "peer_max_lag",
+		Value: float64(spread),
+		Warn:  float64(c.raftSpreadWarn),
+		Crit:  float64(c.raftSpreadCrit),
+		Help:  "Operations between the most and least advanced RAFT peer",
+	})
+
+	switch {
+	case c.raftSpreadCrit > 0 && spread > c.raftSpreadCrit:
+		check.Critical("peer lag spread %d ops", spread)
+	case c.raftSpreadWarn > 0 && spread > c.raftSpreadWarn:
+		check.Warn("peer lag spread %d ops", spread)
+	}
+}
+
 func (c *SrvCheckCmd) optionsFromConsumerMetadata(cfg *api.ConsumerConfig) error {
 	var err error
 
@@ -874,14 +1860,30 @@ func (c *SrvCheckCmd) optionsFromConsumerMetadata(cfg *api.ConsumerConfig) error
 		skip bool
 		fn   func(string) error
 	}{
+		{"io.nats.monitor.outstanding-ack-warn", c.consumerAckOutstandingWarnIsSet, func(v string) error {
+			c.consumerAckOutstandingWarn, err = strconv.Atoi(v)
+			return err
+		}},
 		{"io.nats.monitor.outstanding-ack-critical", c.consumerAckOutstandingCriticalIsSet, func(v string) error {
 			c.consumerAckOutstandingCritical, err = strconv.Atoi(v)
 			return err
 		}},
+		{"io.nats.monitor.ack-pending-warn", c.consumerAckPendingWarnIsSet, func(v string) error {
+			c.consumerAckPendingWarn, err = strconv.Atoi(v)
+			return err
+		}},
+		{"io.nats.monitor.ack-pending-critical", c.consumerAckPendingCritIsSet, func(v string) error {
+			c.consumerAckPendingCrit, err = strconv.Atoi(v)
+			return err
+		}},
 		{"io.nats.monitor.waiting-critical", c.consumerWaitingCriticalIsSet, func(v string) error {
 			c.consumerWaitingCritical, err = strconv.Atoi(v)
 			return err
 		}},
+		{"io.nats.monitor.unprocessed-warn", c.consumerUnprocessedWarnIsSet, func(v string) error {
+			c.consumerUnprocessedWarn, err = strconv.Atoi(v)
+			return err
+		}},
 		{"io.nats.monitor.unprocessed-critical", c.consumerUnprocessedCriticalIsSet, func(v string) error {
 			c.consumerUnprocessedCritical, err = strconv.Atoi(v)
 			return err
@@ -894,6 +1896,10 @@ func (c *SrvCheckCmd) optionsFromConsumerMetadata(cfg *api.ConsumerConfig) error
 			c.consumerLastAckCritical, err = fisk.ParseDuration(v)
 			return err
 		}},
+		{"io.nats.monitor.redelivery-warn", c.consumerRedeliveryWarnIsSet, func(v string) error {
+			c.consumerRedeliveryWarn, err = strconv.Atoi(v)
+			return err
+		}},
 		{"io.nats.monitor.redelivery-critical", c.consumerRedeliveryCriticalIsSet, func(v string) error {
 			c.consumerRedeliveryCritical, err = strconv.Atoi(v)
 			return err
@@ -927,10 +1933,18 @@ func (c *SrvCheckCmd) optionsFromStreamMetadata(cfg *api.StreamConfig) error {
 		skip bool
 		fn   func(string) error
 	}{
+		{"io.nats.monitor.lag-warn", c.sourcesLagWarnIsSet, func(v string) error {
+			c.sourcesLagWarn, err = strconv.ParseUint(v, 10, 64)
+			return err
+		}},
 		{"io.nats.monitor.lag-critical", c.sourcesLagCriticalIsSet, func(v string) error {
 			c.sourcesLagCritical, err = strconv.ParseUint(v, 10, 64)
 			return err
 		}},
+		{"io.nats.monitor.seen-warn", c.sourcesSeenWarnIsSet, func(v string) error {
+			c.sourcesSeenWarn, err = fisk.ParseDuration(v)
+			return err
+		}},
 		{"io.nats.monitor.seen-critical", c.sourcesSeenCriticalIsSet, func(v string) error {
 			c.sourcesSeenCritical, err = fisk.ParseDuration(v)
 			return err
@@ -955,6 +1969,14 @@ func (c *SrvCheckCmd) optionsFromStreamMetadata(cfg *api.StreamConfig) error {
 			c.raftSeenCritical, err = fisk.ParseDuration(v)
 			return err
 		}},
+		{"io.nats.monitor.peer-spread-warn", c.raftSpreadWarnIsSet, func(v string) error {
+			c.raftSpreadWarn, err = strconv.ParseUint(v, 10, 64)
+			return err
+		}},
+		{"io.nats.monitor.peer-spread-critical", c.raftSpreadCritIsSet, func(v string) error {
+			c.raftSpreadCrit, err = strconv.ParseUint(v, 10, 64)
+			return err
+		}},
 		{"io.nats.monitor.msgs-warn", c.streamMessagesWarnIsSet, func(v string) error {
 			c.streamMessagesWarn, err = strconv.ParseUint(v, 10, 64)
 			return err
@@ -971,6 +1993,14 @@ func (c *SrvCheckCmd) optionsFromStreamMetadata(cfg *api.StreamConfig) error {
 			c.subjectsCrit, err = strconv.Atoi(v)
 			return err
 		}},
+		{"io.nats.monitor.bytes-pct-warn", c.streamBytesPctWarnIsSet, func(v string) error {
+			c.streamBytesPctWarn, err = strconv.Atoi(v)
+			return err
+		}},
+		{"io.nats.monitor.bytes-pct-critical", c.streamBytesPctCritIsSet, func(v string) error {
+			c.streamBytesPctCrit, err = strconv.Atoi(v)
+			return err
+		}},
 	}
 
 	for _, m := range meta {
@@ -1019,6 +2049,8 @@ func (c *SrvCheckCmd) checkStream(_ *fisk.ParseContext) error {
 		err = c.checkClusterInfo(check, &sci)
 		check.CriticalIfErr(err, "Invalid cluster data: %s", err)
 
+		c.checkPeerLagSpread(check, &sci)
+
 		if len(check.Criticals) == 0 {
 			check.Ok("%d current replicas", len(info.Cluster.Replicas)+1)
 		}
@@ -1026,7 +2058,30 @@ func (c *SrvCheckCmd) checkStream(_ *fisk.ParseContext) error {
 		check.Critical("not clustered expected %d peers", c.raftExpect)
 	}
 
+	err = c.checkStreamCreated(check, info.Created)
+	check.CriticalIfErr(err, "invalid created time: %s", err)
+
+	c.checkStreamDirect(check, &info.Config)
+	c.checkStreamStorage(check, &info.Config)
+
+	if len(c.expectMetadata) > 0 {
+		c.checkMetadata(check, info.Config.Metadata)
+	}
+
+	var rollupSubjects map[string]uint64
+	if c.streamRollupSubject != "" {
+		rollupSubjects, err = stream.ContainedSubjects(c.streamRollupSubject)
+		check.CriticalIfErr(err, "could not load subjects for %s: %s", c.streamRollupSubject, err)
+	}
+	c.checkStreamRollup(check, &info.Config, rollupSubjects)
+
 	check.Pd(&monitor.PerfDataItem{Name: "messages", Value: float64(info.State.Msgs), Warn: float64(c.streamMessagesWarn), Crit: float64(c.streamMessagesCrit), Help: "Messages stored in the stream"})
+	check.Pd(
+		&monitor.PerfDataItem{Name: "bytes", Value: float64(info.State.Bytes), Unit: "B", Help: "Bytes stored in the stream"},
+		&monitor.PerfDataItem{Name: "consumers", Value: float64(info.State.Consumers), Help: "Number of consumers on the stream"},
+	)
+	c.checkStreamBytes(check, &info.Config, info.State)
+
 	if c.streamMessagesWarn > 0 && info.State.Msgs <= c.streamMessagesWarn {
 		check.Warn("%d messages", info.State.Msgs)
 	}
@@ -1052,12 +2107,15 @@ func (c *SrvCheckCmd) checkStream(_ *fisk.ParseContext) error {
 		}
 	}
 
+	// only problems found with the mirror or sources suppress their OK line
+	crits, warns := len(check.Criticals), len(check.Warnings)
+
 	switch {
-	case stream.IsMirror():
+	case stream.IsMirror() || c.sourcesRequireMirror:
 		err = c.checkMirror(check, info)
 		check.CriticalIfErr(err, "Invalid mirror data: %s", err)
 
-		if len(check.Criticals) == 0 {
+		if len(check.Criticals) == crits && len(check.Warnings) == warns {
 			check.Ok("%s mirror of %s is %d lagged, last seen %s ago", c.sourcesStream, info.Mirror.Name, info.Mirror.Lag, info.Mirror.Active.Round(time.Millisecond))
 		}
 
@@ -1065,7 +2123,7 @@ func (c *SrvCheckCmd) checkStream(_ *fisk.ParseContext) error {
 		err = c.checkSources(check, info)
 		check.CriticalIfErr(err, "Invalid source data: %s", err)
 
-		if len(check.Criticals) == 0 {
+		if len(check.Criticals) == crits && len(check.Warnings) == warns {
 			check.Ok("%d sources", len(info.Sources))
 		}
 	}
@@ -1073,6 +2131,136 @@ func (c *SrvCheckCmd) checkStream(_ *fisk.ParseContext) error {
 	return nil
 }
 
+func (c *SrvCheckCmd) checkStreamBytes(check *monitor.Result, cfg *api.StreamConfig, state api.StreamState) {
+	if c.streamBytesPctWarn <= 0 && c.streamBytesPctCrit <= 0 {
+		return
+	}
+
+	if cfg.MaxBytes <= 0 {
+		check.Ok("no byte limit configured")
+		return
+	}
+
+	pct := float64(state.Bytes) * 100 / float64(cfg.MaxBytes)
+	check.Pd(&monitor.PerfDataItem{Name: "bytes_pct", Value: pct, Unit: "%", Warn: float64(c.streamBytesPctWarn), Crit: float64(c.streamBytesPctCrit), Help: "Bytes stored as a percentage of the stream max bytes"})
+
+	switch {
+	case c.streamBytesPctCrit > 0 && pct >= float64(c.streamBytesPctCrit):
+		check.Critical("%.0f%% of %s max bytes used", pct, humanize.IBytes(uint64(cfg.MaxBytes)))
+	case c.streamBytesPctWarn > 0 && pct >= float64(c.streamBytesPctWarn):
+		check.Warn("%.0f%% of %s max bytes used", pct, humanize.IBytes(uint64(cfg.MaxBytes)))
+	}
+}
+
+func (c *SrvCheckCmd) checkStreamCreated(check *monitor.Result, created time.Time) error {
+	check.Pd(&monitor.PerfDataItem{Name: "created", Value: float64(created.Unix()), Help: "Unix timestamp when the stream was created"})
+
+	if c.streamCreated == "" {
+		return nil
+	}
+
+	expected, err := time.Parse(time.RFC3339, c.streamCreated)
+	if err != nil {
+		return err
+	}
+
+	if !created.Truncate(time.Second).Equal(expected.Truncate(time.Second)) {
+		check.Critical("created %s expected %s", created.UTC().Format(time.RFC3339), expected.UTC().Format(time.RFC3339))
+		return nil
+	}
+
+	check.Ok("created %s", created.UTC().Format(time.RFC3339))
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkStreamDirect(check *monitor.Result, cfg *api.StreamConfig) {
+	if c.streamAllowDirectIsSet {
+		if cfg.AllowDirect != c.streamAllowDirect {
+			check.Critical("allow direct %t expected %t", cfg.AllowDirect, c.streamAllowDirect)
+		} else {
+			check.Ok("allow direct %t", cfg.AllowDirect)
+		}
+	}
+
+	if c.streamMirrorDirectIsSet {
+		if cfg.MirrorDirect != c.streamMirrorDirect {
+			check.Critical("mirror direct %t expected %t", cfg.MirrorDirect, c.streamMirrorDirect)
+		} else {
+			check.Ok("mirror direct %t", cfg.MirrorDirect)
+		}
+	}
+}
+
+func (c *SrvCheckCmd) checkMetadata(check *monitor.Result, metadata map[string]string) {
+	var mismatched []string
+	for k, v := range c.expectMetadata {
+		actual, ok := metadata[k]
+		switch {
+		case !ok:
+			mismatched = append(mismatched, fmt.Sprintf("%s missing", k))
+		case actual != v:
+			mismatched = append(mismatched, fmt.Sprintf("%s=%s", k, actual))
+		}
+	}
+
+	if len(mismatched) > 0 {
+		slices.Sort(mismatched)
+		check.Critical("%d metadata mismatches: %s", len(mismatched), strings.Join(mismatched, ", "))
+		return
+	}
+
+	check.Ok("%d metadata keys match", len(c.expectMetadata))
+}
+
+func (c *SrvCheckCmd) checkStreamStorage(check *monitor.Result, cfg *api.StreamConfig) {
+	if c.streamStorage == "" {
+		return
+	}
+
+	storage := strings.ToLower(cfg.Storage.String())
+	if storage != c.streamStorage {
+		check.Critical("%s storage expected %s", storage, c.streamStorage)
+		return
+	}
+
+	check.Ok("%s storage", storage)
+}
+
+func (c *SrvCheckCmd) checkStreamRollup(check *monitor.Result, cfg *api.StreamConfig, subjects map[string]uint64) {
+	if c.streamAllowRollupIsSet {
+		if cfg.RollupAllowed != c.streamAllowRollup {
+			check.Critical("allow rollup %t expected %t", cfg.RollupAllowed, c.streamAllowRollup)
+		} else {
+			check.Ok("allow rollup %t", cfg.RollupAllowed)
+		}
+	}
+
+	if c.streamRollupSubject == "" {
+		return
+	}
+
+	if len(subjects) == 0 {
+		check.Critical("no messages on %s", c.streamRollupSubject)
+		return
+	}
+
+	var multiple []string
+	for subj, count := range subjects {
+		if count > 1 {
+			multiple = append(multiple, subj)
+		}
+	}
+
+	if len(multiple) > 0 {
+		slices.Sort(multiple)
+		check.Critical("%d subjects with multiple values: %s", len(multiple), strings.Join(multiple, ", "))
+		return
+	}
+
+	check.Ok("%d subjects rolled up", len(subjects))
+}
+
 func (c *SrvCheckCmd) checkMirror(check *monitor.Result, info *api.StreamInfo) error {
 	if info.Mirror == nil {
 		check.Critical("not mirrored")
@@ -1080,16 +2268,20 @@ func (c *SrvCheckCmd) checkMirror(check *monitor.Result, info *api.StreamInfo) e
 	}
 
 	check.Pd(
-		&monitor.PerfDataItem{Name: "lag", Crit: float64(c.sourcesLagCritical), Value: float64(info.Mirror.Lag), Help: "Number of operations this peer is behind its origin"},
-		&monitor.PerfDataItem{Name: "active", Crit: c.sourcesSeenCritical.Seconds(), Unit: "s", Value: info.Mirror.Active.Seconds(), Help: "Indicates if this peer is active and catching up if lagged"},
+		&monitor.PerfDataItem{Name: "lag", Warn: float64(c.sourcesLagWarn), Crit: float64(c.sourcesLagCritical), Value: float64(info.Mirror.Lag), Help: "Number of operations this peer is behind its origin"},
+		&monitor.PerfDataItem{Name: "active", Warn: c.sourcesSeenWarn.Seconds(), Crit: c.sourcesSeenCritical.Seconds(), Unit: "s", Value: info.Mirror.Active.Seconds(), Help: "Indicates if this peer is active and catching up if lagged"},
 	)
 
 	if c.sourcesLagCritical > 0 && info.Mirror.Lag > c.sourcesLagCritical {
 		check.Critical("%d messages behind", info.Mirror.Lag)
+	} else if c.sourcesLagWarn > 0 && info.Mirror.Lag > c.sourcesLagWarn {
+		check.Warn("%d messages behind", info.Mirror.Lag)
 	}
 
 	if c.sourcesSeenCritical > 0 && info.Mirror.Active > c.sourcesSeenCritical {
 		check.Critical("last active %s", info.Mirror.Active)
+	} else if c.sourcesSeenWarn > 0 && info.Mirror.Active > c.sourcesSeenWarn {
+		check.Warn("last active %s", info.Mirror.Active)
 	}
 
 	return nil
@@ -1104,14 +2296,22 @@ func (c *SrvCheckCmd) checkSources(check *monitor.Result, info *api.StreamInfo)
 
 	lagged := 0
 	inactive := 0
+	laggedWarn := 0
+	inactiveWarn := 0
 
 	for _, s := range info.Sources {
-		if c.sourcesLagCritical > 0 && s.Lag > c.sourcesLagCritical {
+		switch {
+		case c.sourcesLagCritical > 0 && s.Lag > c.sourcesLagCritical:
 			lagged++
+		case c.sourcesLagWarn > 0 && s.Lag > c.sourcesLagWarn:
+			laggedWarn++
 		}
 
-		if c.sourcesSeenCritical > 0 && s.Active > c.sourcesSeenCritical {
+		switch {
+		case c.sourcesSeenCritical > 0 && s.Active > c.sourcesSeenCritical:
 			inactive++
+		case c.sourcesSeenWarn > 0 && s.Active > c.sourcesSeenWarn:
+			inactiveWarn++
 		}
 	}
 
@@ -1126,6 +2326,12 @@ func (c *SrvCheckCmd) checkSources(check *monitor.Result, info *api.StreamInfo)
 	if inactive > 0 {
 		check.Critical("%d inactive sources", inactive)
 	}
+	if laggedWarn > 0 {
+		check.Warn("%d lagged sources", laggedWarn)
+	}
+	if inactiveWarn > 0 {
+		check.Warn("%d inactive sources", inactiveWarn)
+	}
 	if len(info.Sources) < c.sourcesMinSources {
 		check.Critical("%d sources of min expected %d", len(info.Sources), c.sourcesMinSources)
 	}
@@ -1186,132 +2392,1937 @@ func (c *SrvCheckCmd) checkStreamMessage(mgr *jsm.Manager, check *monitor.Result
 	return nil
 }
 
-func (c *SrvCheckCmd) checkMsg(_ *fisk.ParseContext) error {
-	check := &monitor.Result{Name: "Stream Message", Check: "message", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
-	defer check.GenericExit()
-
-	_, mgr, err := prepareHelper("", natsOpts()...)
-	check.CriticalIfErr(err, "connection failed")
+func (c *SrvCheckCmd) checkSubjectMapping(check *monitor.Result, info *server.AccountInfo) error {
+	if info == nil {
+		return fmt.Errorf("no data received")
+	}
 
-	return c.checkStreamMessage(mgr, check)
-}
+	weightsString := func(w map[string]int) string {
+		var res []string
+		for subj, weight := range w {
+			res = append(res, fmt.Sprintf("%s=%d", subj, weight))
+		}
+		slices.Sort(res)
 
-func (c *SrvCheckCmd) checkConnection(_ *fisk.ParseContext) error {
-	check := &monitor.Result{Name: "Connection", Check: "connections", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
-	defer check.GenericExit()
+		return strings.Join(res, ", ")
+	}
 
-	connStart := time.Now()
-	nc, _, err := prepareHelper("", natsOpts()...)
-	check.CriticalIfErr(err, "connection failed")
+	expected := map[string]int{}
+	for subj, weight := range c.mapDests {
+		w, err := strconv.Atoi(weight)
+		if err != nil {
+			return fmt.Errorf("invalid weight for %s: %v", subj, err)
+		}
+		expected[subj] = w
+	}
 
-	ct := time.Since(connStart)
-	check.Pd(&monitor.PerfDataItem{Name: "connect_time", Value: ct.Seconds(), Warn: c.connectWarning.Seconds(), Crit: c.connectCritical.Seconds(), Unit: "s", Help: "Time taken to connect to NATS"})
+	dests, ok := info.Mappings[c.mapSource]
+	if !ok {
+		check.Critical("no mapping for %s", c.mapSource)
+		return nil
+	}
 
-	if ct >= c.connectCritical {
-		check.Critical("connected to %s, connect time exceeded %v", nc.ConnectedUrl(), c.connectCritical)
-	} else if ct >= c.connectWarning {
-		check.Warn("connected to %s, connect time exceeded %v", nc.ConnectedUrl(), c.connectWarning)
-	} else {
-		check.Ok("connected to %s in %s", nc.ConnectedUrl(), ct)
+	observed := map[string]int{}
+	for _, d := range dests {
+		observed[d.Subject] += int(d.Weight)
 	}
 
-	rtt, err := nc.RTT()
-	check.CriticalIfErr(err, "rtt failed: %s", err)
+	check.Pd(&monitor.PerfDataItem{Name: "destinations", Value: float64(len(observed)), Help: "Destinations of the subject mapping"})
 
-	check.Pd(&monitor.PerfDataItem{Name: "rtt", Value: rtt.Seconds(), Warn: c.rttWarning.Seconds(), Crit: c.rttCritical.Seconds(), Unit: "s", Help: "The round-trip-time of the connection"})
-	if rtt >= c.rttCritical {
-		check.Critical("rtt time exceeded %v", c.rttCritical)
-	} else if rtt >= c.rttWarning {
-		check.Critical("rtt time exceeded %v", c.rttWarning)
-	} else {
-		check.Ok("rtt time %v", rtt)
+	if !maps.Equal(observed, expected) {
+		check.Critical("Mapping %s weights %s expected %s", c.mapSource, weightsString(observed), weightsString(expected))
+		return nil
 	}
 
-	msg := []byte(randomPassword(100))
-	ib := nc.NewRespInbox()
-	sub, err := nc.SubscribeSync(ib)
-	check.CriticalIfErr(err, "could not subscribe to %s: %s", ib, err)
-	sub.AutoUnsubscribe(1)
-
-	start := time.Now()
-	err = nc.Publish(ib, msg)
-	check.CriticalIfErr(err, "could not publish to %s: %s", ib, err)
+	check.Ok("Mapping %s weights %s", c.mapSource, weightsString(observed))
 
-	received, err := sub.NextMsg(opts().Timeout)
-	check.CriticalIfErr(err, "did not receive from %s: %s", ib, err)
+	return nil
+}
 
-	reqt := time.Since(start)
-	check.Pd(&monitor.PerfDataItem{Name: "request_time", Value: reqt.Seconds(), Warn: c.reqWarning.Seconds(), Crit: c.reqCritical.Seconds(), Unit: "s", Help: "Time taken for a full Request-Reply operation"})
+func (c *SrvCheckCmd) checkMappingAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.mapSource, Check: "mapping", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
 
-	if !bytes.Equal(received.Data, msg) {
-		check.Critical("did not receive expected message")
-	}
+	accountz := &server.Accountz{}
+	err := c.fetchServerData("$SYS.REQ.SERVER.PING.ACCOUNTZ", server.AccountzEventOptions{AccountzOptions: server.AccountzOptions{Account: c.mapAccount}, EventFilterOptions: server.EventFilterOptions{Name: c.srvName}}, accountz)
+	check.CriticalIfErr(err, "accountz failed: %s", err)
 
-	if reqt >= c.reqCritical {
-		check.Critical("round trip request took %f", reqt.Seconds())
-	} else if reqt >= c.reqWarning {
-		check.Warn("round trip request took %f", reqt.Seconds())
-	} else {
-		check.Ok("round trip took %fs", reqt.Seconds())
-	}
+	err = c.checkSubjectMapping(check, accountz.Account)
+	check.CriticalIfErr(err, "check failed: %s", err)
 
 	return nil
 }
 
-func (c *SrvCheckCmd) checkCredential(check *monitor.Result) error {
-	ok, err := fileAccessible(c.credential)
+type accountUsage struct {
+	conns       int
+	busiestSubs uint32
+}
+
+// sampleAccountUsage gathers usage from every server, connection limits apply
+// to the whole cluster while subscription limits apply to each connection
+// accountStatz requests the statistics for a single account from every server,
+// unused accounts are included so that idle accounts report zero usage
+func (c *SrvCheckCmd) accountStatz(nc *nats.Conn, account string) ([]*server.AccountStat, error) {
+	res, err := doReq(server.AccountStatzEventOptions{AccountStatzOptions: server.AccountStatzOptions{Accounts: []string{account}, IncludeUnused: true}}, "$SYS.REQ.ACCOUNT.PING.STATZ", 0, nc)
 	if err != nil {
-		check.Critical("credential not accessible: %v", err)
-		return nil
+		return nil, err
 	}
 
-	if !ok {
-		check.Critical("credential not accessible")
-		return nil
+	if len(res) == 0 {
+		return nil, fmt.Errorf("did not get results from any servers")
 	}
 
-	cb, err := os.ReadFile(c.credential)
-	if err != nil {
-		check.Critical("credential not accessible: %v", err)
-		return nil
+	return c.matchAccountStats(res, account)
+}
+
+// matchAccountStats extracts the statistics for account from STATZ responses,
+// servers without an entry for the account do not contribute any usage
+func (c *SrvCheckCmd) matchAccountStats(res [][]byte, account string) ([]*server.AccountStat, error) {
+	var matched []*server.AccountStat
+	act := &actCmd{}
+
+	for _, r := range res {
+		sz, err := act.parseAccountStatResp(r)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, stats := range sz.Stats.Accounts {
+			if stats.Account == account {
+				matched = append(matched, stats)
+			}
+		}
 	}
 
-	token, err := nkeys.ParseDecoratedJWT(cb)
+	return matched, nil
+}
+
+func (c *SrvCheckCmd) sampleAccountUsage(nc *nats.Conn) (*accountUsage, error) {
+	stats, err := c.accountStatz(nc, c.acctName)
 	if err != nil {
-		check.Critical("invalid credential: %v", err)
-		return nil
+		return nil, err
 	}
 
-	claims, err := jwt.Decode(token)
-	if err != nil {
-		check.Critical("invalid credential: %v", err)
+	usage := &accountUsage{}
+	for _, s := range stats {
+		usage.conns += s.Conns
 	}
 
-	now := time.Now().UTC().Unix()
-	cd := claims.Claims()
-	until := cd.Expires - now
-	crit := int64(c.credentialValidityCrit.Seconds())
-	warn := int64(c.credentialValidityWarn.Seconds())
+	res, err := doReq(&server.ConnzEventOptions{ConnzOptions: server.ConnzOptions{Account: c.acctName, Sort: server.BySubs, Limit: 1}}, "$SYS.REQ.SERVER.PING.CONNZ", 0, nc)
+	if err != nil {
+		return nil, err
+	}
 
-	check.Pd(&monitor.PerfDataItem{Help: "Expiry time in seconds", Name: "expiry", Value: float64(until), Warn: float64(warn), Crit: float64(crit), Unit: "s"})
+	for _, r := range res {
+		co, err := parseConnzResp(r)
+		if err != nil {
+			return nil, err
+		}
 
-	switch {
-	case cd.Expires == 0 && c.credentialRequiresExpire:
-		check.Critical("never expires")
-	case c.credentialValidityCrit > 0 && (until <= crit):
-		check.Critical("expires sooner than %s", f(c.credentialValidityCrit))
-	case c.credentialValidityWarn > 0 && (until <= warn):
-		check.Warn("expires sooner than %s", f(c.credentialValidityWarn))
-	default:
-		check.Ok("expires in %s", time.Unix(cd.Expires, 0).UTC())
+		for _, conn := range co.Data.Conns {
+			usage.busiestSubs = max(usage.busiestSubs, conn.NumSubs)
+		}
 	}
 
-	return nil
+	return usage, nil
 }
 
-func (c *SrvCheckCmd) checkCredentialAction(_ *fisk.ParseContext) error {
-	check := &monitor.Result{Name: "Credential", Check: "credential", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
-	defer check.GenericExit()
+func (c *SrvCheckCmd) checkAccountLimits(check *monitor.Result, info *server.AccountInfo, usage *accountUsage) error {
+	if info == nil || usage == nil {
+		return fmt.Errorf("no account information")
+	}
 
-	return c.checkCredential(check)
+	var connLimit, subsLimit int64 = -1, -1
+	if info.Claim != nil {
+		connLimit = info.Claim.Limits.Conn
+		subsLimit = info.Claim.Limits.Subs
+	}
+
+	checkVal := func(item string, desc string, warn int, crit int, limit int64, current int64) {
+		check.Pd(
+			&monitor.PerfDataItem{Name: item, Value: float64(current), Help: fmt.Sprintf("Account %s", desc)},
+			&monitor.PerfDataItem{Name: item + "_limit", Value: float64(limit), Help: fmt.Sprintf("Account limit for %s, -1 when unlimited", desc)},
+		)
+
+		if warn == -1 && crit == -1 {
+			return
+		}
+
+		if warn != -1 && crit != -1 && warn >= crit {
+			check.Critical("%s: invalid thresholds", item)
+			return
+		}
+
+		if limit <= 0 {
+			check.Ok("%d %s, no limit configured", current, desc)
+			return
+		}
+
+		pct := float64(current) * 100 / float64(limit)
+		check.Pd(&monitor.PerfDataItem{Name: item + "_pct", Value: pct, Unit: "%", Warn: float64(warn), Crit: float64(crit), Help: fmt.Sprintf("Account %s in percent of the limit", desc)})
+
+		switch {
+		case crit > -1 && pct >= float64(crit):
+			check.Critical("%.0f%% of %d %s", pct, limit, desc)
+		case warn > -1 && pct >= float64(warn):
+			check.Warn("%.0f%% of %d %s", pct, limit, desc)
+		default:
+			check.Ok("%d of %d %s", current, limit, desc)
+		}
+	}
+
+	checkVal("connections", "connections", c.acctConnWarn, c.acctConnCrit, connLimit, int64(usage.conns))
+	checkVal("connection_subscriptions", "subscriptions on the busiest connection", c.acctSubsWarn, c.acctSubsCrit, subsLimit, int64(usage.busiestSubs))
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkAccountLimitsAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.acctName, Check: "account", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	accountz := &server.Accountz{}
+	err := c.fetchServerData("$SYS.REQ.SERVER.PING.ACCOUNTZ", server.AccountzEventOptions{AccountzOptions: server.AccountzOptions{Account: c.acctName}, EventFilterOptions: server.EventFilterOptions{Name: c.srvName}}, accountz)
+	check.CriticalIfErr(err, "accountz failed: %s", err)
+
+	nc, _, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	usage, err := c.sampleAccountUsage(nc)
+	check.CriticalIfErr(err, "account usage failed: %s", err)
+
+	err = c.checkAccountLimits(check, accountz.Account, usage)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkAssetVisibility(check *monitor.Result, known func(stream string) (bool, error)) error {
+	expected := len(c.visStreams) + len(c.visBuckets)
+	if expected == 0 {
+		return fmt.Errorf("no streams or buckets to check")
+	}
+
+	var unreachable []string
+
+	lookup := func(kind string, name string, stream string) error {
+		ok, err := known(stream)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			unreachable = append(unreachable, fmt.Sprintf("%s %s", kind, name))
+		}
+
+		return nil
+	}
+
+	for _, s := range c.visStreams {
+		err := lookup("stream", s, s)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, b := range c.visBuckets {
+		err := lookup("bucket", b, "KV_"+b)
+		if err != nil {
+			return err
+		}
+	}
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "expected", Value: float64(expected), Help: "Streams and Buckets expected to be reachable"},
+		&monitor.PerfDataItem{Name: "reachable", Value: float64(expected - len(unreachable)), Help: "Streams and Buckets that are reachable"},
+	)
+
+	if len(unreachable) > 0 {
+		check.Critical("%d of %d unreachable: %s", len(unreachable), expected, strings.Join(unreachable, ", "))
+		return nil
+	}
+
+	check.Ok("%d reachable", expected)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkVisibilityAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "JetStream Visibility", Check: "visibility", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	err = c.checkAssetVisibility(check, mgr.IsKnownStream)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkHeaderPresence(check *monitor.Result, msgs []*api.StoredMsg) error {
+	if c.hdrMissingWarn > -1 && c.hdrMissingCrit > -1 && c.hdrMissingWarn >= c.hdrMissingCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	if len(msgs) == 0 {
+		check.Critical("no messages found")
+		return nil
+	}
+
+	missing := 0
+	var absent []string
+
+	for _, msg := range msgs {
+		var hdrs nats.Header
+		if len(msg.Header) > 0 {
+			var err error
+			hdrs, err = decodeHeadersMsg(msg.Header)
+			if err != nil {
+				return fmt.Errorf("invalid headers in message %d: %v", msg.Sequence, err)
+			}
+		}
+
+		found := true
+		for _, h := range c.hdrNames {
+			if hdrs.Get(h) == "" {
+				found = false
+				if !slices.Contains(absent, h) {
+					absent = append(absent, h)
+				}
+			}
+		}
+
+		if !found {
+			missing++
+		}
+	}
+
+	pct := float64(missing) * 100 / float64(len(msgs))
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "messages", Value: float64(len(msgs)), Help: "Messages inspected for required headers"},
+		&monitor.PerfDataItem{Name: "missing_headers", Value: pct, Warn: float64(c.hdrMissingWarn), Crit: float64(c.hdrMissingCrit), Unit: "%", Help: "Messages missing required headers in percent"},
+	)
+
+	switch {
+	case c.hdrMissingCrit > -1 && pct > float64(c.hdrMissingCrit):
+		check.Critical("%d of %d messages missing headers: %s", missing, len(msgs), strings.Join(absent, ", "))
+	case c.hdrMissingWarn > -1 && pct > float64(c.hdrMissingWarn):
+		check.Warn("%d of %d messages missing headers: %s", missing, len(msgs), strings.Join(absent, ", "))
+	default:
+		check.Ok("%d of %d messages missing headers", missing, len(msgs))
+	}
+
+	return nil
+}
+
+// hdrMaxSkipped bounds the deleted messages skipped while looking for recent
+// messages so that sparse streams do not need millions of reads
+const hdrMaxSkipped = 1000
+
+func (c *SrvCheckCmd) recentMessages(check *monitor.Result, state api.StreamState, read func(seq uint64) (*api.StoredMsg, error)) ([]*api.StoredMsg, error) {
+	attempts := c.hdrCount + min(state.NumDeleted, hdrMaxSkipped)
+	reads := 0
+
+	var msgs []*api.StoredMsg
+	for seq := state.LastSeq; seq >= state.FirstSeq && seq > 0 && len(msgs) < c.hdrCount; seq-- {
+		if reads == attempts {
+			check.Warn("found %d of %d messages within %d reads", len(msgs), c.hdrCount, reads)
+			break
+		}
+		reads++
+
+		msg, err := read(seq)
+		if jsm.IsNatsError(err, 10037) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+func (c *SrvCheckCmd) checkHeadersAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.sourcesStream, Check: "headers", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	stream, err := mgr.LoadStream(c.sourcesStream)
+	check.CriticalIfErr(err, "could not load stream %s: %s", c.sourcesStream, err)
+
+	info, err := stream.LatestInformation()
+	check.CriticalIfErr(err, "could not load stream %s info: %s", c.sourcesStream, err)
+
+	msgs, err := c.recentMessages(check, info.State, stream.ReadMessage)
+	check.CriticalIfErr(err, "msg load failed: %v", err)
+
+	err = c.checkHeaderPresence(check, msgs)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkMsg(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "Stream Message", Check: "message", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed")
+
+	return c.checkStreamMessage(mgr, check)
+}
+
+// rttAtPercentile uses the nearest rank method so that the result is always one of the samples
+func (c *SrvCheckCmd) rttAtPercentile(samples []time.Duration) (time.Duration, error) {
+	if len(samples) == 0 {
+		return 0, fmt.Errorf("no samples")
+	}
+
+	if c.rttPercentile < 1 || c.rttPercentile > 100 {
+		return 0, fmt.Errorf("invalid percentile %d", c.rttPercentile)
+	}
+
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+
+	rank := (c.rttPercentile*len(sorted) + 99) / 100
+
+	return sorted[rank-1], nil
+}
+
+func (c *SrvCheckCmd) checkConnectionRTT(check *monitor.Result, rtt time.Duration) {
+	check.Pd(&monitor.PerfDataItem{Name: "rtt", Value: rtt.Seconds(), Warn: c.rttWarning.Seconds(), Crit: c.rttCritical.Seconds(), Unit: "s", Help: "The round-trip-time of the connection at the configured percentile"})
+
+	switch {
+	case rtt >= c.rttCritical:
+		check.Critical("rtt time exceeded %v", c.rttCritical)
+	case rtt >= c.rttWarning:
+		check.Warn("rtt time exceeded %v", c.rttWarning)
+	default:
+		check.Ok("rtt time %v", rtt)
+	}
+}
+
+func (c *SrvCheckCmd) checkConnection(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "Connection", Check: "connections", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	connStart := time.Now()
+	nc, _, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed")
+
+	ct := time.Since(connStart)
+	check.Pd(&monitor.PerfDataItem{Name: "connect_time", Value: ct.Seconds(), Warn: c.connectWarning.Seconds(), Crit: c.connectCritical.Seconds(), Unit: "s", Help: "Time taken to connect to NATS"})
+
+	if ct >= c.connectCritical {
+		check.Critical("connected to %s, connect time exceeded %v", nc.ConnectedUrl(), c.connectCritical)
+	} else if ct >= c.connectWarning {
+		check.Warn("connected to %s, connect time exceeded %v", nc.ConnectedUrl(), c.connectWarning)
+	} else {
+		check.Ok("connected to %s in %s", nc.ConnectedUrl(), ct)
+	}
+
+	var samples []time.Duration
+	for i := 0; i < max(c.rttSamples, 1); i++ {
+		rtt, err := nc.RTT()
+		check.CriticalIfErr(err, "rtt failed: %s", err)
+		samples = append(samples, rtt)
+	}
+
+	rtt, err := c.rttAtPercentile(samples)
+	check.CriticalIfErr(err, "rtt failed: %s", err)
+
+	c.checkConnectionRTT(check, rtt)
+
+	msg := []byte(randomPassword(100))
+	ib := nc.NewRespInbox()
+	sub, err := nc.SubscribeSync(ib)
+	check.CriticalIfErr(err, "could not subscribe to %s: %s", ib, err)
+	sub.AutoUnsubscribe(1)
+
+	start := time.Now()
+	err = nc.Publish(ib, msg)
+	check.CriticalIfErr(err, "could not publish to %s: %s", ib, err)
+
+	received, err := sub.NextMsg(opts().Timeout)
+	check.CriticalIfErr(err, "did not receive from %s: %s", ib, err)
+
+	reqt := time.Since(start)
+	check.Pd(&monitor.PerfDataItem{Name: "request_time", Value: reqt.Seconds(), Warn: c.reqWarning.Seconds(), Crit: c.reqCritical.Seconds(), Unit: "s", Help: "Time taken for a full Request-Reply operation"})
+
+	if !bytes.Equal(received.Data, msg) {
+		check.Critical("did not receive expected message")
+	}
+
+	if reqt >= c.reqCritical {
+		check.Critical("round trip request took %f", reqt.Seconds())
+	} else if reqt >= c.reqWarning {
+		check.Warn("round trip request took %f", reqt.Seconds())
+	} else {
+		check.Ok("round trip took %fs", reqt.Seconds())
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkKnownServers(check *monitor.Result, servers []server.ServerInfo) error {
+	if len(servers) == 0 {
+		return fmt.Errorf("no servers discovered")
+	}
+
+	var unknown []string
+	for _, srv := range servers {
+		if !slices.Contains(c.knownServers, srv.Name) && !slices.Contains(c.knownServers, srv.ID) {
+			unknown = append(unknown, fmt.Sprintf("%s (%s)", srv.Name, srv.ID))
+		}
+	}
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "servers", Value: float64(len(servers)), Help: "Number of servers discovered"},
+		&monitor.PerfDataItem{Name: "unknown_servers", Value: float64(len(unknown)), Help: "Number of discovered servers not in the list of known servers"},
+	)
+
+	if len(unknown) > 0 {
+		check.Critical("%d unknown servers: %s", len(unknown), strings.Join(unknown, ", "))
+		return nil
+	}
+
+	check.Ok("%d known servers", len(servers))
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkKnownServersAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "Known Servers", Check: "servers", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	nc, _, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	res, err := doReq(nil, "$SYS.REQ.SERVER.PING", 0, nc)
+	check.CriticalIfErr(err, "could not discover servers: %s", err)
+
+	var servers []server.ServerInfo
+	for _, r := range res {
+		ssm := &server.ServerStatsMsg{}
+		err = json.Unmarshal(r, ssm)
+		check.CriticalIfErr(err, "invalid result received: %s", err)
+
+		servers = append(servers, ssm.Server)
+	}
+
+	err = c.checkKnownServers(check, servers)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkObjectIntegrity(check *monitor.Result, obs nats.ObjectStore) error {
+	info, err := obs.GetInfo(c.objName)
+	if errors.Is(err, nats.ErrObjectNotFound) {
+		check.Critical("object %s not found", c.objName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	check.Pd(&monitor.PerfDataItem{Name: "size", Value: float64(info.Size), Unit: "B", Help: "The size of the object"})
+
+	if c.objMaxSize > 0 && info.Size > uint64(c.objMaxSize) {
+		check.Ok("object %s of %s not verified, larger than %s", c.objName, humanize.IBytes(info.Size), humanize.IBytes(uint64(c.objMaxSize)))
+		return nil
+	}
+
+	start := time.Now()
+	res, err := obs.Get(c.objName)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, res)
+	readTime := time.Since(start)
+	if err != nil && !errors.Is(err, nats.ErrDigestMismatch) {
+		return err
+	}
+
+	check.Pd(&monitor.PerfDataItem{Name: "read_time", Value: readTime.Seconds(), Unit: "s", Help: "Time taken to read the object"})
+
+	digest := nats.GetObjectDigestValue(h)
+	if digest != info.Digest {
+		check.Critical("object %s digest %s expected %s", c.objName, digest, info.Digest)
+		return nil
+	}
+
+	check.Ok("object %s of %s verified in %v", c.objName, humanize.IBytes(info.Size), readTime.Round(time.Millisecond))
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkObjectAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.objBucket, Check: "object", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	nc, _, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	js, err := nc.JetStream()
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	obs, err := js.ObjectStore(c.objBucket)
+	if errors.Is(err, nats.ErrStreamNotFound) || errors.Is(err, nats.ErrBucketNotFound) {
+		check.Critical("bucket %s not found", c.objBucket)
+		return nil
+	}
+	check.CriticalIfErr(err, "could not load bucket: %s", err)
+
+	err = c.checkObjectIntegrity(check, obs)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkObjectStoreStatus(check *monitor.Result, obs nats.ObjectStore, stream *jsm.Stream) error {
+	status, err := obs.Status()
+	if err != nil {
+		return err
+	}
+
+	// listing the bucket fetches the info of every object, instead the chunk
+	// subjects are counted, deleted objects keep their info but have their
+	// chunks purged so they are not counted. Empty objects and links have no
+	// chunks and are not counted either
+	chunks, err := stream.ContainedSubjects(fmt.Sprintf("$O.%s.C.>", status.Bucket()))
+	if err != nil {
+		return err
+	}
+	objects := len(chunks)
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "objects", Value: float64(objects), Warn: float64(c.objObjectsWarn), Crit: float64(c.objObjectsCrit), Help: "How many objects holding data are stored in the bucket"},
+		&monitor.PerfDataItem{Name: "bytes", Value: float64(status.Size()), Warn: float64(c.objBytesWarn), Crit: float64(c.objBytesCrit), Unit: "B", Help: "Bytes stored in the bucket"},
+		&monitor.PerfDataItem{Name: "replicas", Value: float64(status.Replicas())},
+	)
+
+	checkVal := func(item string, warn int64, crit int64, value int64) {
+		if warn == -1 && crit == -1 {
+			return
+		}
+
+		if crit > -1 && crit < warn {
+			if value <= crit {
+				check.Critical("%d %s", value, item)
+			} else if value <= warn {
+				check.Warn("%d %s", value, item)
+			} else {
+				check.Ok("%d %s", value, item)
+			}
+		} else {
+			if crit > -1 && value >= crit {
+				check.Critical("%d %s", value, item)
+			} else if warn > -1 && value >= warn {
+				check.Warn("%d %s", value, item)
+			} else {
+				check.Ok("%d %s", value, item)
+			}
+		}
+	}
+
+	checkVal("objects", int64(c.objObjectsWarn), int64(c.objObjectsCrit), int64(objects))
+	checkVal("bytes", int64(c.objBytesWarn), int64(c.objBytesCrit), int64(status.Size()))
+
+	if c.objName == "" {
+		check.Ok("bucket %s", c.objBucket)
+		return nil
+	}
+
+	info, err := obs.GetInfo(c.objName)
+	if err != nil && !errors.Is(err, nats.ErrObjectNotFound) {
+		return err
+	}
+
+	switch {
+	case info == nil:
+		check.Critical("object %s not found", c.objName)
+	case c.objMinSize > 0 && info.Size < uint64(c.objMinSize):
+		check.Critical("object %s of %s is smaller than %s", c.objName, humanize.IBytes(info.Size), humanize.IBytes(uint64(c.objMinSize)))
+	case c.objMaxSize > 0 && info.Size > uint64(c.objMaxSize):
+		check.Critical("object %s of %s is larger than %s", c.objName, humanize.IBytes(info.Size), humanize.IBytes(uint64(c.objMaxSize)))
+	default:
+		check.Ok("object %s of %s found", c.objName, humanize.IBytes(info.Size))
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkObjectStoreAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.objBucket, Check: "objectstore", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	nc, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	js, err := nc.JetStream()
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	obs, err := js.ObjectStore(c.objBucket)
+	if errors.Is(err, nats.ErrStreamNotFound) || errors.Is(err, nats.ErrBucketNotFound) {
+		check.Critical("bucket %s not found", c.objBucket)
+		return nil
+	}
+	check.CriticalIfErr(err, "could not load bucket: %s", err)
+
+	stream, err := mgr.LoadStream("OBJ_" + c.objBucket)
+	check.CriticalIfErr(err, "could not load bucket stream: %s", err)
+
+	err = c.checkObjectStoreStatus(check, obs, stream)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) verifyTLSChain(certs []*x509.Certificate, roots *x509.CertPool) error {
+	if len(certs) == 0 {
+		return fmt.Errorf("no certificates presented")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, DNSName: c.tlsHostname})
+
+	return err
+}
+
+// tlsRoots picks the pool to verify against, the --ca bundle takes precedence
+// over the CA configured in the connection options, a nil pool means the
+// system roots are used
+func (c *SrvCheckCmd) tlsRoots(o *nats.Options, roots *x509.CertPool) (*x509.CertPool, error) {
+	switch {
+	case roots != nil:
+		return roots, nil
+	case o.RootCAsCB != nil:
+		return o.RootCAsCB()
+	case o.TLSConfig != nil:
+		return o.TLSConfig.RootCAs, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (c *SrvCheckCmd) checkTLSChain(check *monitor.Result, certs []*x509.Certificate, roots *x509.CertPool) error {
+	if len(certs) == 0 {
+		return fmt.Errorf("no certificates presented")
+	}
+
+	leaf := certs[0]
+
+	err := c.verifyTLSChain(certs, roots)
+	if err != nil {
+		check.Critical("%s issued by %s: %v", leaf.Subject, leaf.Issuer, err)
+	} else {
+		check.Ok("%s issued by %s", leaf.Subject, leaf.Issuer)
+	}
+
+	// expiry is reported even for certificates that fail verification
+	c.checkCertificateExpiry(check, "expiry", leaf)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkCertificateExpiry(check *monitor.Result, name string, cert *x509.Certificate) {
+	until := time.Until(cert.NotAfter)
+
+	check.Pd(&monitor.PerfDataItem{Name: name, Value: until.Seconds(), Warn: c.tlsValidityWarn.Seconds(), Crit: c.tlsValidityCrit.Seconds(), Unit: "s", Help: "Time before the certificate expires"})
+
+	switch {
+	case c.tlsValidityCrit > 0 && until <= c.tlsValidityCrit:
+		check.Critical("%s expires %s", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339))
+	case c.tlsValidityWarn > 0 && until <= c.tlsValidityWarn:
+		check.Warn("%s expires %s", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339))
+	default:
+		check.Ok("%s expires %s", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+}
+
+func (c *SrvCheckCmd) checkClientCertificate(check *monitor.Result, file string) error {
+	if file == "" {
+		check.Critical("no client certificate configured")
+		return nil
+	}
+
+	pb, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	block, _ := pem.Decode(pb)
+	if block == nil {
+		return fmt.Errorf("no certificate found in %s", file)
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return err
+	}
+
+	c.checkCertificateExpiry(check, "client_expiry", cert)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkTLSAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "TLS", Check: "tls", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	// a nil pool verifies against the system roots
+	var roots *x509.CertPool
+	if c.tlsCA != "" {
+		pem, err := os.ReadFile(c.tlsCA)
+		check.CriticalIfErr(err, "could not read CA bundle: %s", err)
+
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			check.Critical("no certificates found in %s", c.tlsCA)
+			return nil
+		}
+	}
+
+	// the chain is verified against the supplied CA during the handshake so that
+	// credentials are never sent to a server presenting an untrusted certificate
+	var certs []*x509.Certificate
+	verify := func(o *nats.Options) error {
+		var err error
+		roots, err = c.tlsRoots(o, roots)
+		if err != nil {
+			return err
+		}
+
+		if o.TLSConfig == nil {
+			o.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+
+		o.Secure = true
+		o.TLSConfig.InsecureSkipVerify = true
+		o.TLSConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			if c.tlsHostname == "" {
+				c.tlsHostname = cs.ServerName
+			}
+
+			certs = cs.PeerCertificates
+			return c.verifyTLSChain(certs, roots)
+		}
+
+		return nil
+	}
+
+	_, _, err := prepareHelper("", append(natsOpts(), verify)...)
+	if len(certs) == 0 {
+		check.CriticalIfErr(err, "connection failed: %s", err)
+	}
+
+	err = c.checkTLSChain(check, certs, roots)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	if c.tlsClientCert {
+		var file string
+		if opts().Config != nil {
+			file = opts().Config.Certificate()
+		}
+
+		err = c.checkClientCertificate(check, file)
+		check.CriticalIfErr(err, "could not load client certificate: %s", err)
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkRoutes(check *monitor.Result, vz *server.Varz, rz *server.Routez, resolve func(host string) ([]string, error)) error {
+	if vz == nil || rz == nil {
+		return fmt.Errorf("no data received")
+	}
+
+	self := func(ips []string, port string) bool {
+		switch vz.Cluster.Host {
+		case "", "0.0.0.0", "::":
+			return false
+		}
+
+		return port == strconv.Itoa(vz.Cluster.Port) && slices.Contains(ips, vz.Cluster.Host)
+	}
+
+	type configuredRoute struct {
+		url       string
+		ips       []string
+		port      string
+		connected bool
+	}
+
+	var candidates []*configuredRoute
+	var absent []string
+
+	for _, u := range vz.Cluster.URLs {
+		if slices.Contains(c.routesIgnore, u) {
+			continue
+		}
+
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return fmt.Errorf("invalid route url %s: %v", u, err)
+		}
+
+		ips, err := resolve(parsed.Hostname())
+		if err != nil {
+			candidates = append(candidates, &configuredRoute{url: u})
+			continue
+		}
+
+		if self(ips, parsed.Port()) {
+			continue
+		}
+
+		candidates = append(candidates, &configuredRoute{url: u, ips: ips, port: parsed.Port()})
+	}
+
+	// duplicate route resolution might keep the connection the remote server
+	// solicited, those have an ephemeral port so only the IP can be matched.
+	// Route pooling opens several connections to the same remote server so
+	// each remote server can only satisfy one configured route
+	remote := func(r *server.RouteInfo) string {
+		if r.RemoteID != "" {
+			return r.RemoteID
+		}
+		return strconv.FormatUint(r.Rid, 10)
+	}
+
+	claimed := map[string]bool{}
+	match := func(solicited bool) {
+		for _, cand := range candidates {
+			if cand.connected || len(cand.ips) == 0 {
+				continue
+			}
+
+			for _, r := range rz.Routes {
+				if claimed[remote(r)] || !slices.Contains(cand.ips, r.IP) {
+					continue
+				}
+				if solicited && !(r.DidSolicit && strconv.Itoa(r.Port) == cand.port) {
+					continue
+				}
+
+				claimed[remote(r)] = true
+				cand.connected = true
+				break
+			}
+		}
+	}
+
+	match(true)
+	match(false)
+
+	for _, cand := range candidates {
+		if !cand.connected {
+			absent = append(absent, cand.url)
+		}
+	}
+
+	configured := len(candidates)
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "configured_routes", Value: float64(configured), Help: "Configured cluster routes"},
+		&monitor.PerfDataItem{Name: "connected_routes", Value: float64(configured - len(absent)), Help: "Configured cluster routes that are connected"},
+		&monitor.PerfDataItem{Name: "routes", Value: float64(rz.NumRoutes), Help: "Cluster routes including those solicited by other servers"},
+	)
+
+	if len(absent) > 0 {
+		check.Critical("%d of %d configured routes not connected: %s", len(absent), configured, strings.Join(absent, ", "))
+		return nil
+	}
+
+	check.Ok("%d configured routes connected", configured)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkRoutesAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.srvName, Check: "routes", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	vz, err := c.fetchVarz()
+	check.CriticalIfErr(err, "could not retrieve VARZ information: %s", err)
+
+	rz := &server.Routez{}
+	err = c.fetchServerData("$SYS.REQ.SERVER.PING.ROUTEZ", server.RoutezEventOptions{EventFilterOptions: server.EventFilterOptions{Name: c.srvName}}, rz)
+	check.CriticalIfErr(err, "could not retrieve ROUTEZ information: %s", err)
+
+	err = c.checkRoutes(check, vz, rz, net.LookupHost)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkLeafnodes(check *monitor.Result, lz *server.Leafz) error {
+	if lz == nil {
+		return fmt.Errorf("no data received")
+	}
+
+	if c.leafsWarn > -1 && c.leafsCrit > -1 && c.leafsWarn < c.leafsCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	check.Pd(&monitor.PerfDataItem{Name: "leafnodes", Value: float64(lz.NumLeafs), Warn: float64(c.leafsWarn), Crit: float64(c.leafsCrit), Help: "Connected leafnodes"})
+
+	var absent []string
+	for _, remote := range c.leafRemotes {
+		found := slices.ContainsFunc(lz.Leafs, func(l *server.LeafInfo) bool {
+			return l.Name == remote
+		})
+		if !found {
+			absent = append(absent, remote)
+		}
+	}
+
+	if len(absent) > 0 {
+		check.Critical("%d of %d leafnodes not connected: %s", len(absent), len(c.leafRemotes), strings.Join(absent, ", "))
+	}
+
+	switch {
+	case c.leafsCrit > -1 && lz.NumLeafs < c.leafsCrit:
+		check.Critical("%d leafnodes connected", lz.NumLeafs)
+	case c.leafsWarn > -1 && lz.NumLeafs < c.leafsWarn:
+		check.Warn("%d leafnodes connected", lz.NumLeafs)
+	default:
+		check.Ok("%d leafnodes connected", lz.NumLeafs)
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkLeafnodesAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.srvName, Check: "leafnodes", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	lz := &server.Leafz{}
+	err := c.fetchServerData("$SYS.REQ.SERVER.PING.LEAFZ", server.LeafzEventOptions{EventFilterOptions: server.EventFilterOptions{Name: c.srvName}}, lz)
+	check.CriticalIfErr(err, "could not retrieve LEAFZ information: %s", err)
+
+	err = c.checkLeafnodes(check, lz)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkGateways(check *monitor.Result, gwz *server.Gatewayz) error {
+	if gwz == nil {
+		return fmt.Errorf("no data received")
+	}
+
+	if c.gwOutboundWarn > -1 && c.gwOutboundCrit > -1 && c.gwOutboundWarn < c.gwOutboundCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	if c.gwInboundWarn > -1 && c.gwInboundCrit > -1 && c.gwInboundWarn < c.gwInboundCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	var outbound, inbound int
+	var disconnected []string
+
+	for name, gw := range gwz.OutboundGateways {
+		switch {
+		case gw.Connection != nil:
+			outbound++
+		case gw.IsConfigured:
+			disconnected = append(disconnected, name)
+		}
+	}
+
+	for _, gws := range gwz.InboundGateways {
+		inbound += len(gws)
+	}
+
+	for _, remote := range c.gwRemotes {
+		gw, ok := gwz.OutboundGateways[remote]
+		if (!ok || gw.Connection == nil) && !slices.Contains(disconnected, remote) {
+			disconnected = append(disconnected, remote)
+		}
+	}
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "outbound_gateways", Value: float64(outbound), Warn: float64(c.gwOutboundWarn), Crit: float64(c.gwOutboundCrit), Help: "Connected outbound gateways"},
+		&monitor.PerfDataItem{Name: "inbound_gateways", Value: float64(inbound), Warn: float64(c.gwInboundWarn), Crit: float64(c.gwInboundCrit), Help: "Connected inbound gateway connections"},
+	)
+
+	if len(disconnected) > 0 {
+		slices.Sort(disconnected)
+		check.Critical("%d gateways not connected: %s", len(disconnected), strings.Join(disconnected, ", "))
+	}
+
+	checkVal := func(item string, warn int, crit int, value int) {
+		switch {
+		case crit > -1 && value < crit:
+			check.Critical("%d %s gateways", value, item)
+		case warn > -1 && value < warn:
+			check.Warn("%d %s gateways", value, item)
+		}
+	}
+
+	checkVal("outbound", c.gwOutboundWarn, c.gwOutboundCrit, outbound)
+	checkVal("inbound", c.gwInboundWarn, c.gwInboundCrit, inbound)
+
+	if len(check.Criticals) == 0 && len(check.Warnings) == 0 {
+		check.Ok("%d outbound %d inbound gateways", outbound, inbound)
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkGatewaysAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.srvName, Check: "gateways", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	gwz := &server.Gatewayz{}
+	err := c.fetchServerData("$SYS.REQ.SERVER.PING.GATEWAYZ", server.GatewayzEventOptions{EventFilterOptions: server.EventFilterOptions{Name: c.srvName}}, gwz)
+	check.CriticalIfErr(err, "could not retrieve GATEWAYZ information: %s", err)
+
+	err = c.checkGateways(check, gwz)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkAvailability(check *monitor.Result, probe func() error) error {
+	if c.availAttempts < 1 {
+		return fmt.Errorf("at least 1 attempt is required")
+	}
+
+	if c.availWarn > -1 && c.availCrit > -1 && c.availWarn < c.availCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	var success int
+	var lastErr error
+
+	for i := 0; i < c.availAttempts; i++ {
+		if i > 0 {
+			time.Sleep(c.availInterval)
+		}
+
+		err := probe()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		success++
+	}
+
+	availability := float64(success) * 100 / float64(c.availAttempts)
+
+	check.Pd(&monitor.PerfDataItem{Name: "availability", Value: availability, Warn: float64(c.availWarn), Crit: float64(c.availCrit), Unit: "%", Help: "Percentage of attempts that succeeded"})
+
+	switch {
+	case c.availCrit > -1 && availability < float64(c.availCrit):
+		check.Critical("%.0f%% available over %d attempts, last error: %v", availability, c.availAttempts, lastErr)
+	case c.availWarn > -1 && availability < float64(c.availWarn):
+		check.Warn("%.0f%% available over %d attempts, last error: %v", availability, c.availAttempts, lastErr)
+	default:
+		check.Ok("%.0f%% available over %d attempts", availability, c.availAttempts)
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkAvailabilityAction(_ *fisk.ParseContext) error {
+	name := c.sourcesStream
+	if c.consumerName != "" {
+		name = fmt.Sprintf("%s_%s", c.sourcesStream, c.consumerName)
+	}
+
+	check := &monitor.Result{Name: name, Check: "availability", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	err = c.checkAvailability(check, func() error {
+		if c.consumerName != "" {
+			_, err := mgr.LoadConsumer(c.sourcesStream, c.consumerName)
+			return err
+		}
+
+		_, err := mgr.LoadStream(c.sourcesStream)
+		return err
+	})
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkHandshakes(check *monitor.Result, connect func() (*nats.Conn, error)) error {
+	if c.hsAttempts < 1 {
+		return fmt.Errorf("at least 1 attempt is required")
+	}
+
+	if c.hsFailWarn > -1 && c.hsFailCrit > -1 && c.hsFailWarn >= c.hsFailCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	var failed int
+	var total time.Duration
+
+	for i := 0; i < c.hsAttempts; i++ {
+		start := time.Now()
+		nc, err := connect()
+		took := time.Since(start)
+		total += took
+
+		if err != nil {
+			failed++
+			continue
+		}
+		nc.Close()
+
+		if c.hsSlow > 0 && took > c.hsSlow {
+			failed++
+		}
+	}
+
+	failedPct := float64(failed) * 100 / float64(c.hsAttempts)
+	avg := total / time.Duration(c.hsAttempts)
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "handshake_success", Value: 100 - failedPct, Unit: "%", Help: "Successful handshakes in percent"},
+		&monitor.PerfDataItem{Name: "handshake_time", Value: avg.Seconds(), Unit: "s", Help: "Average time taken to complete a handshake"},
+	)
+
+	switch {
+	case c.hsFailCrit > -1 && failedPct > float64(c.hsFailCrit):
+		check.Critical("%d of %d handshakes failed", failed, c.hsAttempts)
+	case c.hsFailWarn > -1 && failedPct > float64(c.hsFailWarn):
+		check.Warn("%d of %d handshakes failed", failed, c.hsAttempts)
+	default:
+		check.Ok("%d of %d handshakes failed, average %v", failed, c.hsAttempts, avg.Round(time.Millisecond))
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkHandshakeAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "Handshake", Check: "handshake", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	if opts().Config == nil {
+		check.Critical("no context loaded")
+		return nil
+	}
+
+	err := c.checkHandshakes(check, func() (*nats.Conn, error) {
+		return nats.Connect(opts().Config.ServerURL(), natsOpts()...)
+	})
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkPublish(check *monitor.Result, nc *nats.Conn, mgr *jsm.Manager) error {
+	js, err := nc.JetStream(nats.MaxWait(opts().Timeout))
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	ack, err := js.Publish(c.pubSubject, []byte(c.reqPayload))
+	pubt := time.Since(start)
+
+	switch {
+	case errors.Is(err, nats.ErrNoStreamResponse), errors.Is(err, nats.ErrNoResponders):
+		check.Critical("no stream on %s", c.pubSubject)
+		return nil
+	case errors.Is(err, nats.ErrTimeout):
+		check.Critical("no acknowledgement on %s within %v", c.pubSubject, opts().Timeout)
+		return nil
+	case err != nil:
+		return err
+	}
+
+	check.Pd(&monitor.PerfDataItem{Name: "ack_time", Value: pubt.Seconds(), Warn: c.pubWarning.Seconds(), Crit: c.pubCritical.Seconds(), Unit: "s", Help: "Time taken for JetStream to acknowledge the message"})
+
+	switch {
+	case c.pubCritical > 0 && pubt >= c.pubCritical:
+		check.Critical("acknowledged by %s took %v", ack.Stream, pubt.Round(time.Millisecond))
+	case c.pubWarning > 0 && pubt >= c.pubWarning:
+		check.Warn("acknowledged by %s took %v", ack.Stream, pubt.Round(time.Millisecond))
+	default:
+		check.Ok("acknowledged by %s in %v", ack.Stream, pubt.Round(time.Millisecond))
+	}
+
+	if c.pubPurge {
+		stream, err := mgr.LoadStream(ack.Stream)
+		if err != nil {
+			return err
+		}
+
+		err = stream.Purge(&api.JSApiStreamPurgeRequest{Subject: c.pubSubject})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkPublishAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.pubSubject, Check: "publish", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	nc, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	err = c.checkPublish(check, nc, mgr)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkRoundtrip(check *monitor.Result, nc *nats.Conn, mgr *jsm.Manager) error {
+	js, err := nc.JetStream(nats.MaxWait(opts().Timeout))
+	if err != nil {
+		return err
+	}
+
+	sub, err := js.SubscribeSync(c.pubSubject, nats.DeliverNew(), nats.AckNone())
+	if errors.Is(err, nats.ErrNoMatchingStream) {
+		check.Critical("no stream on %s", c.pubSubject)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	id := nuid.Next()
+	start := time.Now()
+
+	ack, err := js.Publish(c.pubSubject, []byte(c.reqPayload), nats.MsgId(id))
+	switch {
+	case errors.Is(err, nats.ErrNoStreamResponse), errors.Is(err, nats.ErrNoResponders):
+		check.Critical("no stream on %s", c.pubSubject)
+		return nil
+	case errors.Is(err, nats.ErrTimeout):
+		check.Critical("no acknowledgement on %s within %v", c.pubSubject, opts().Timeout)
+		return nil
+	case err != nil:
+		return err
+	}
+
+	// other publishers on the subject could be delivered before our message
+	for {
+		remaining := opts().Timeout - time.Since(start)
+		if remaining <= 0 {
+			check.Critical("message not received on %s within %v", c.pubSubject, opts().Timeout)
+			return nil
+		}
+
+		msg, err := sub.NextMsg(remaining)
+		if errors.Is(err, nats.ErrTimeout) {
+			check.Critical("message not received on %s within %v", c.pubSubject, opts().Timeout)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if msg.Header.Get(nats.MsgIdHdr) == id {
+			break
+		}
+	}
+
+	rtt := time.Since(start)
+
+	check.Pd(&monitor.PerfDataItem{Name: "roundtrip_time", Value: rtt.Seconds(), Warn: c.pubWarning.Seconds(), Crit: c.pubCritical.Seconds(), Unit: "s", Help: "Time taken for a published message to be delivered by the stream"})
+
+	switch {
+	case c.pubCritical > 0 && rtt >= c.pubCritical:
+		check.Critical("delivered by %s took %v", ack.Stream, rtt.Round(time.Millisecond))
+	case c.pubWarning > 0 && rtt >= c.pubWarning:
+		check.Warn("delivered by %s took %v", ack.Stream, rtt.Round(time.Millisecond))
+	default:
+		check.Ok("delivered by %s in %v", ack.Stream, rtt.Round(time.Millisecond))
+	}
+
+	if c.pubPurge {
+		stream, err := mgr.LoadStream(ack.Stream)
+		if err != nil {
+			return err
+		}
+
+		err = stream.Purge(&api.JSApiStreamPurgeRequest{Subject: c.pubSubject})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkRoundtripAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.pubSubject, Check: "roundtrip", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	nc, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	err = c.checkRoundtrip(check, nc, mgr)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkResponders(check *monitor.Result, nc *nats.Conn) error {
+	if c.respWarn > -1 && c.respCrit > -1 && c.respWarn < c.respCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	responders := map[string]struct{}{}
+	failed := 0
+
+	for i := 0; i < c.respRequests; i++ {
+		msg, err := nc.Request(c.reqSubject, []byte(c.reqPayload), opts().Timeout)
+		switch {
+		case errors.Is(err, nats.ErrNoResponders), errors.Is(err, nats.ErrTimeout):
+			failed++
+			continue
+		case err != nil:
+			return err
+		}
+
+		id := msg.Header.Get(c.respIDHeader)
+		if id == "" {
+			failed++
+			continue
+		}
+
+		responders[id] = struct{}{}
+	}
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "responders", Value: float64(len(responders)), Warn: float64(c.respWarn), Crit: float64(c.respCrit), Help: "Distinct responders that answered requests"},
+		&monitor.PerfDataItem{Name: "failed_requests", Value: float64(failed), Help: "Requests without a response or responder identity"},
+	)
+
+	switch {
+	case c.respCrit > -1 && len(responders) < c.respCrit:
+		check.Critical("%d responders on %s", len(responders), c.reqSubject)
+	case c.respWarn > -1 && len(responders) < c.respWarn:
+		check.Warn("%d responders on %s", len(responders), c.reqSubject)
+	default:
+		check.Ok("%d responders on %s", len(responders), c.reqSubject)
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkRespondersAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.reqSubject, Check: "responders", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	nc, _, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	err = c.checkResponders(check, nc)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkRequest(check *monitor.Result, nc *nats.Conn) error {
+	start := time.Now()
+	msg, err := nc.Request(c.reqSubject, []byte(c.reqPayload), opts().Timeout)
+	reqt := time.Since(start)
+
+	switch {
+	case errors.Is(err, nats.ErrNoResponders):
+		check.Critical("no responders on %s", c.reqSubject)
+		return nil
+	case errors.Is(err, nats.ErrTimeout):
+		check.Critical("no response on %s within %v", c.reqSubject, opts().Timeout)
+		return nil
+	case err != nil:
+		return err
+	}
+
+	check.Pd(&monitor.PerfDataItem{Name: "request_time", Value: reqt.Seconds(), Warn: c.reqWarning.Seconds(), Crit: c.reqCritical.Seconds(), Unit: "s", Help: "Time taken for the service to respond"})
+
+	if c.msgRegexp != nil && !c.msgRegexp.Match(msg.Data) {
+		check.Critical("response does not match regex: %s", c.msgRegexp.String())
+		return nil
+	}
+
+	switch {
+	case c.reqCritical > 0 && reqt >= c.reqCritical:
+		check.Critical("response on %s took %v", c.reqSubject, reqt.Round(time.Millisecond))
+	case c.reqWarning > 0 && reqt >= c.reqWarning:
+		check.Warn("response on %s took %v", c.reqSubject, reqt.Round(time.Millisecond))
+	default:
+		check.Ok("response on %s in %v", c.reqSubject, reqt.Round(time.Millisecond))
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkRequestAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.reqSubject, Check: "request", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	nc, _, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	err = c.checkRequest(check, nc)
+	check.CriticalIfErr(err, "request failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkCredential(check *monitor.Result) error {
+	credential := os.ExpandEnv(c.credential)
+	if credential == "" {
+		check.Critical("credential path %q expanded to an empty value", c.credential)
+		return nil
+	}
+
+	ok, err := fileAccessible(credential)
+	if err != nil {
+		check.Critical("credential not accessible: %v", err)
+		return nil
+	}
+
+	if !ok {
+		check.Critical("credential not accessible")
+		return nil
+	}
+
+	cb, err := os.ReadFile(credential)
+	if err != nil {
+		check.Critical("credential not accessible: %v", err)
+		return nil
+	}
+
+	token, err := nkeys.ParseDecoratedJWT(cb)
+	if err != nil {
+		check.Critical("invalid credential: %v", err)
+		return nil
+	}
+
+	claims, err := jwt.Decode(token)
+	if err != nil {
+		check.Critical("invalid credential: %v", err)
+	}
+
+	now := time.Now().UTC().Unix()
+	cd := claims.Claims()
+	until := cd.Expires - now
+	crit := int64(c.credentialValidityCrit.Seconds())
+	warn := int64(c.credentialValidityWarn.Seconds())
+
+	check.Pd(&monitor.PerfDataItem{Help: "Expiry time in seconds", Name: "expiry", Value: float64(until), Warn: float64(warn), Crit: float64(crit), Unit: "s"})
+
+	switch {
+	case cd.Expires == 0 && c.credentialRequiresExpire:
+		check.Critical("never expires")
+	case c.credentialValidityCrit > 0 && (until <= crit):
+		check.Critical("expires sooner than %s", f(c.credentialValidityCrit))
+	case c.credentialValidityWarn > 0 && (until <= warn):
+		check.Warn("expires sooner than %s", f(c.credentialValidityWarn))
+	default:
+		check.Ok("expires in %s", time.Unix(cd.Expires, 0).UTC())
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkCredentialAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "Credential", Check: "credential", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	return c.checkCredential(check)
+}
+
+type accountThroughputSample struct {
+	time     time.Time
+	sent     server.DataStats
+	received server.DataStats
+	conns    int
+}
+
+func (c *SrvCheckCmd) sampleAccountThroughput(nc *nats.Conn) (*accountThroughputSample, error) {
+	stats, err := c.accountStatz(nc, c.acctName)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &accountThroughputSample{time: time.Now()}
+
+	for _, s := range stats {
+		sample.sent.Msgs += s.Sent.Msgs
+		sample.sent.Bytes += s.Sent.Bytes
+		sample.received.Msgs += s.Received.Msgs
+		sample.received.Bytes += s.Received.Bytes
+		sample.conns += s.Conns
+	}
+
+	return sample, nil
+}
+
+func (c *SrvCheckCmd) checkAccountThroughput(check *monitor.Result, first *accountThroughputSample, second *accountThroughputSample) error {
+	if first == nil || second == nil {
+		return fmt.Errorf("no data received")
+	}
+
+	elapsed := second.time.Sub(first.time).Seconds()
+	if elapsed <= 0 {
+		return fmt.Errorf("invalid sample interval")
+	}
+
+	rate := func(a int64, b int64) float64 {
+		// counters reset when servers restart between samples
+		if b < a {
+			return 0
+		}
+
+		return float64(b-a) / elapsed
+	}
+
+	checkRate := func(name string, help string, value float64, warn int64, crit int64) {
+		check.Pd(&monitor.PerfDataItem{Name: name, Value: value, Warn: float64(warn), Crit: float64(crit), Help: help})
+
+		if warn > -1 && crit > -1 && warn >= crit {
+			check.Critical("%s: invalid thresholds", name)
+			return
+		}
+
+		switch {
+		case crit > -1 && value >= float64(crit):
+			check.Critical("%s %.2f/s", name, value)
+		case warn > -1 && value >= float64(warn):
+			check.Warn("%s %.2f/s", name, value)
+		}
+	}
+
+	inMsgs := rate(first.received.Msgs, second.received.Msgs)
+	inBytes := rate(first.received.Bytes, second.received.Bytes)
+	outMsgs := rate(first.sent.Msgs, second.sent.Msgs)
+	outBytes := rate(first.sent.Bytes, second.sent.Bytes)
+
+	checkRate("in_msgs_rate", "Messages per second received from clients", inMsgs, int64(c.throughputInMsgsWarn), int64(c.throughputInMsgsCrit))
+	checkRate("in_bytes_rate", "Bytes per second received from clients", inBytes, int64(c.throughputInBytesWarn), int64(c.throughputInBytesCrit))
+	checkRate("out_msgs_rate", "Messages per second sent to clients", outMsgs, int64(c.throughputOutMsgsWarn), int64(c.throughputOutMsgsCrit))
+	checkRate("out_bytes_rate", "Bytes per second sent to clients", outBytes, int64(c.throughputOutBytesWarn), int64(c.throughputOutBytesCrit))
+
+	if len(check.Criticals) == 0 && len(check.Warnings) == 0 {
+		check.Ok("%.2f msgs/s in %.2f msgs/s out", inMsgs, outMsgs)
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkThroughputAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "Account Throughput", Check: "throughput", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	nc, _, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	first, err := c.sampleAccountThroughput(nc)
+	check.CriticalIfErr(err, "could not retrieve account statistics: %s", err)
+
+	time.Sleep(c.throughputInterval)
+
+	second, err := c.sampleAccountThroughput(nc)
+	check.CriticalIfErr(err, "could not retrieve account statistics: %s", err)
+
+	err = c.checkAccountThroughput(check, first, second)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+type streamSequenceSample struct {
+	time    time.Time
+	lastSeq uint64
+}
+
+func (c *SrvCheckCmd) checkMessageRate(check *monitor.Result, first *streamSequenceSample, second *streamSequenceSample) error {
+	if first == nil || second == nil {
+		return fmt.Errorf("no data received")
+	}
+
+	if c.msgRateMinWarn > -1 && c.msgRateMinCrit > -1 && c.msgRateMinWarn < c.msgRateMinCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	if c.msgRateMaxWarn > -1 && c.msgRateMaxCrit > -1 && c.msgRateMaxWarn > c.msgRateMaxCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	elapsed := second.time.Sub(first.time).Seconds()
+	if elapsed <= 0 {
+		return fmt.Errorf("invalid sample interval")
+	}
+
+	var rate float64
+	// the stream could have been recreated between samples
+	if second.lastSeq > first.lastSeq {
+		rate = float64(second.lastSeq-first.lastSeq) / elapsed
+	}
+
+	check.Pd(&monitor.PerfDataItem{Name: "msgs_rate", Value: rate, Warn: float64(c.msgRateMaxWarn), Crit: float64(c.msgRateMaxCrit), Help: "Messages per second added to the stream"})
+
+	switch {
+	case c.msgRateMinCrit > -1 && rate <= float64(c.msgRateMinCrit):
+		check.Critical("%.2f msgs/s", rate)
+	case c.msgRateMaxCrit > -1 && rate >= float64(c.msgRateMaxCrit):
+		check.Critical("%.2f msgs/s", rate)
+	case c.msgRateMinWarn > -1 && rate <= float64(c.msgRateMinWarn):
+		check.Warn("%.2f msgs/s", rate)
+	case c.msgRateMaxWarn > -1 && rate >= float64(c.msgRateMaxWarn):
+		check.Warn("%.2f msgs/s", rate)
+	default:
+		check.Ok("%.2f msgs/s", rate)
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkMessageRateAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.sourcesStream, Check: "messagerate", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	stream, err := mgr.LoadStream(c.sourcesStream)
+	check.CriticalIfErr(err, "could not load stream %s: %s", c.sourcesStream, err)
+
+	sample := func() (*streamSequenceSample, error) {
+		nfo, err := stream.LatestInformation()
+		if err != nil {
+			return nil, err
+		}
+
+		return &streamSequenceSample{time: time.Now(), lastSeq: nfo.State.LastSeq}, nil
+	}
+
+	first, err := sample()
+	check.CriticalIfErr(err, "could not load stream %s info: %s", c.sourcesStream, err)
+
+	time.Sleep(c.msgRateInterval)
+
+	second, err := sample()
+	check.CriticalIfErr(err, "could not load stream %s info: %s", c.sourcesStream, err)
+
+	err = c.checkMessageRate(check, first, second)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkAssets(check *monitor.Result, jsz *server.JSInfo) error {
+	if jsz == nil {
+		return fmt.Errorf("no data received")
+	}
+
+	if jsz.Disabled {
+		check.Critical("JetStream not enabled")
+		return nil
+	}
+
+	assets := jsz.Streams + jsz.Consumers
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "assets", Value: float64(assets), Warn: float64(c.assetsWarn), Crit: float64(c.assetsCrit), Help: "Number of Streams and Consumers hosted on the server"},
+		&monitor.PerfDataItem{Name: "streams", Value: float64(jsz.Streams), Help: "Number of Streams hosted on the server"},
+		&monitor.PerfDataItem{Name: "consumers", Value: float64(jsz.Consumers), Help: "Number of Consumers hosted on the server"},
+	)
+
+	if c.assetsWarn > -1 && c.assetsCrit > -1 && c.assetsWarn >= c.assetsCrit {
+		check.Critical("assets: invalid thresholds")
+		return nil
+	}
+
+	switch {
+	case c.assetsCrit > -1 && assets >= c.assetsCrit:
+		check.Critical("%d assets", assets)
+	case c.assetsWarn > -1 && assets >= c.assetsWarn:
+		check.Warn("%d assets", assets)
+	default:
+		check.Ok("%d assets", assets)
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkAssetsAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: c.srvName, Check: "assets", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	jsz := &server.JSInfo{}
+	err := c.fetchServerData("$SYS.REQ.SERVER.PING.JSZ", server.JszEventOptions{EventFilterOptions: server.EventFilterOptions{Name: c.srvName}}, jsz)
+	check.CriticalIfErr(err, "could not retrieve JSZ information: %s", err)
+
+	err = c.checkAssets(check, jsz)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) nonConformingNames(pattern *regexp.Regexp, names []string) []string {
+	var bad []string
+	for _, name := range names {
+		if !pattern.MatchString(name) {
+			bad = append(bad, name)
+		}
+	}
+
+	return bad
+}
+
+func (c *SrvCheckCmd) checkNamingViolations(check *monitor.Result, checked int, bad []string) {
+	check.Pd(&monitor.PerfDataItem{Name: "names", Value: float64(checked), Help: "Number of names checked against the naming convention"})
+	check.Pd(&monitor.PerfDataItem{Name: "violations", Value: float64(len(bad)), Warn: float64(c.namingViolationsWarn), Crit: float64(c.namingViolationsCrit), Help: "Number of names not matching the naming convention"})
+
+	switch {
+	case c.namingViolationsCrit > -1 && len(bad) >= c.namingViolationsCrit && len(bad) > 0:
+		check.Critical("%d non-conforming names: %s", len(bad), strings.Join(bad, ", "))
+	case c.namingViolationsWarn > -1 && len(bad) >= c.namingViolationsWarn && len(bad) > 0:
+		check.Warn("%d non-conforming names: %s", len(bad), strings.Join(bad, ", "))
+	default:
+		check.Ok("%d names checked", checked)
+	}
+}
+
+func (c *SrvCheckCmd) checkNamingAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "Naming", Check: "naming", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	if c.namingStream == nil && c.namingConsumer == nil {
+		check.Critical("a stream or consumer naming pattern is required")
+		return nil
+	}
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	streams, err := mgr.StreamNames(nil)
+	check.CriticalIfErr(err, "could not list streams: %s", err)
+
+	var bad []string
+	checked := 0
+
+	if c.namingStream != nil {
+		checked += len(streams)
+		bad = append(bad, c.nonConformingNames(c.namingStream, streams)...)
+	}
+
+	if c.namingConsumer != nil {
+		for _, stream := range streams {
+			consumers, err := mgr.ConsumerNames(stream)
+			check.CriticalIfErr(err, "could not list consumers for stream %s: %s", stream, err)
+
+			checked += len(consumers)
+			for _, name := range c.nonConformingNames(c.namingConsumer, consumers) {
+				bad = append(bad, fmt.Sprintf("%s > %s", stream, name))
+			}
+		}
+	}
+
+	c.checkNamingViolations(check, checked, bad)
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkConnectionGrowth(check *monitor.Result, samples []*accountThroughputSample) error {
+	if len(samples) < 2 {
+		return fmt.Errorf("at least 2 samples are required")
+	}
+
+	if c.connGrowthWarn > -1 && c.connGrowthCrit > -1 && c.connGrowthWarn >= c.connGrowthCrit {
+		return fmt.Errorf("invalid thresholds")
+	}
+
+	first := samples[0]
+	last := samples[len(samples)-1]
+
+	elapsed := last.time.Sub(first.time).Seconds()
+	if elapsed <= 0 {
+		return fmt.Errorf("invalid sample interval")
+	}
+
+	monotonic := true
+	for i := 1; i < len(samples); i++ {
+		if samples[i].conns < samples[i-1].conns {
+			monotonic = false
+			break
+		}
+	}
+
+	growth := last.conns - first.conns
+	rate := float64(growth) / elapsed
+
+	check.Pd(
+		&monitor.PerfDataItem{Name: "connections", Value: float64(last.conns), Help: "Connections in the account"},
+		&monitor.PerfDataItem{Name: "connection_growth", Value: float64(growth), Warn: float64(c.connGrowthWarn), Crit: float64(c.connGrowthCrit), Help: "Connections gained over the sample window"},
+		&monitor.PerfDataItem{Name: "connection_growth_rate", Value: rate, Help: "Connections gained per second over the sample window"},
+	)
+
+	if monotonic && growth > 0 {
+		switch {
+		case c.connGrowthCrit > -1 && growth >= c.connGrowthCrit:
+			check.Critical("%d connections gained without disconnects over %s", growth, last.time.Sub(first.time).Round(time.Second))
+		case c.connGrowthWarn > -1 && growth >= c.connGrowthWarn:
+			check.Warn("%d connections gained without disconnects over %s", growth, last.time.Sub(first.time).Round(time.Second))
+		}
+	}
+
+	if len(check.Criticals) == 0 && len(check.Warnings) == 0 {
+		check.Ok("%d connections, %d gained over %s", last.conns, growth, last.time.Sub(first.time).Round(time.Second))
+	}
+
+	return nil
+}
+
+func (c *SrvCheckCmd) checkConnectionGrowthAction(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "Connection Growth", Check: "connection_growth", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	nc, _, err := prepareHelper("", natsOpts()...)
+	check.CriticalIfErr(err, "connection failed: %s", err)
+
+	var samples []*accountThroughputSample
+	for i := 0; i < c.connGrowthSamples; i++ {
+		if i > 0 {
+			time.Sleep(c.connGrowthInterval)
+		}
+
+		sample, err := c.sampleAccountThroughput(nc)
+		check.CriticalIfErr(err, "could not retrieve account statistics: %s", err)
+
+		samples = append(samples, sample)
+	}
+
+	err = c.checkConnectionGrowth(check, samples)
+	check.CriticalIfErr(err, "check failed: %s", err)
+
+	return nil
 }
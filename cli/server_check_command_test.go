@@ -14,16 +14,30 @@
 package cli
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"github.com/nats-io/natscli/options"
+	"math/big"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/choria-io/fisk/units"
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jwt/v2"
 	"github.com/nats-io/nats-server/v2/server"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/natscli/monitor"
@@ -103,6 +117,60 @@ func TestCheckConsumer(t *testing.T) {
 		assertListEquals(t, check.Criticals, "Ack Pending: 300")
 	})
 
+	t.Run("Ack Pending Headroom", func(t *testing.T) {
+		cmd := &SrvCheckCmd{sourcesStream: "TEST", consumerName: "CONS", consumerAckPendingWarn: 80, consumerAckPendingCrit: 95}
+		check := &monitor.Result{}
+
+		cmd.checkConsumerStatus(check, api.ConsumerInfo{
+			NumAckPending: 500,
+			Config:        api.ConsumerConfig{MaxAckPending: 1000},
+		})
+		assertListIsEmpty(t, check.Warnings)
+		assertListIsEmpty(t, check.Criticals)
+		assertHasPDItem(t, check, "ack_pending_pct=50%;80;95")
+
+		check = &monitor.Result{}
+		cmd.checkConsumerStatus(check, api.ConsumerInfo{
+			NumAckPending: 850,
+			Config:        api.ConsumerConfig{MaxAckPending: 1000},
+		})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "Ack Pending: 85% of 1000")
+
+		check = &monitor.Result{}
+		cmd.checkConsumerStatus(check, api.ConsumerInfo{
+			NumAckPending: 1000,
+			Config:        api.ConsumerConfig{MaxAckPending: 1000},
+		})
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.Criticals, "Ack Pending: 100% of 1000")
+	})
+
+	t.Run("Warnings", func(t *testing.T) {
+		cmd := &SrvCheckCmd{
+			sourcesStream: "TEST", consumerName: "CONS",
+			consumerAckOutstandingWarn: 50, consumerAckOutstandingCritical: 100,
+			consumerUnprocessedWarn: 50, consumerUnprocessedCritical: 100,
+			consumerRedeliveryWarn: 50, consumerRedeliveryCritical: 100,
+		}
+		check := &monitor.Result{}
+
+		cmd.checkConsumerStatus(check, api.ConsumerInfo{NumAckPending: 10, NumPending: 10, NumRedelivered: 10})
+		assertListIsEmpty(t, check.Warnings)
+		assertListIsEmpty(t, check.Criticals)
+		assertHasPDItem(t, check, "ack_pending=10;50;100", "pending=10;50;100", "redelivered=10;50;100")
+
+		check = &monitor.Result{}
+		cmd.checkConsumerStatus(check, api.ConsumerInfo{NumAckPending: 60, NumPending: 60, NumRedelivered: 60})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "Ack Pending: 60", "Unprocessed Messages: 60", "Redelivered Messages: 60")
+
+		check = &monitor.Result{}
+		cmd.checkConsumerStatus(check, api.ConsumerInfo{NumAckPending: 200, NumPending: 200, NumRedelivered: 200})
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.Criticals, "Ack Pending: 200", "Unprocessed Messages: 200", "Redelivered Messages: 200")
+	})
+
 	t.Run("Waiting Pulls", func(t *testing.T) {
 		cmd := &SrvCheckCmd{sourcesStream: "TEST", consumerName: "CONS", consumerWaitingCritical: 100}
 		check := &monitor.Result{}
@@ -233,6 +301,219 @@ func TestCheckConsumer(t *testing.T) {
 	})
 }
 
+func TestCheckConsumerBackoff(t *testing.T) {
+	cmd := &SrvCheckCmd{consumerBackoff: []time.Duration{time.Second, 5 * time.Second}}
+
+	t.Run("matching", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerBackoff(check, api.ConsumerInfo{Config: api.ConsumerConfig{BackOff: []time.Duration{time.Second, 5 * time.Second}}})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "Backoff 1s, 5s")
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerBackoff(check, api.ConsumerInfo{Config: api.ConsumerConfig{BackOff: []time.Duration{time.Second, 10 * time.Second}}})
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "Backoff 1s, 10s expected 1s, 5s")
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerBackoff(check, api.ConsumerInfo{})
+		assertListEquals(t, check.Criticals, "Backoff none expected 1s, 5s")
+	})
+}
+
+func TestCheckConsumerFilterOverlap(t *testing.T) {
+	cmd := &SrvCheckCmd{consumerFilterOverlap: true}
+	scfg := &api.StreamConfig{Subjects: []string{"orders.*", "invoices.>"}}
+
+	t.Run("no filters", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerFilterOverlap(check, scfg, &api.ConsumerConfig{})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "No filter subjects")
+	})
+
+	t.Run("no stream subjects", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerFilterOverlap(check, &api.StreamConfig{}, &api.ConsumerConfig{FilterSubject: "orders.new"})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "Stream has no subjects, filter overlap not checked")
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerFilterOverlap(check, scfg, &api.ConsumerConfig{FilterSubjects: []string{"orders.new", "invoices.paid.>"}})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "Filter subjects overlap the stream subjects")
+	})
+
+	t.Run("no overlap", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerFilterOverlap(check, scfg, &api.ConsumerConfig{FilterSubject: "order.new", FilterSubjects: []string{"invoices.paid", "orders.new.x"}})
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "Filter subjects do not overlap the stream subjects: order.new, orders.new.x")
+	})
+}
+
+func TestCheckConsumerDurable(t *testing.T) {
+	cmd := &SrvCheckCmd{consumerDurable: true}
+
+	check := &monitor.Result{}
+	cmd.checkConsumerDurable(check, api.ConsumerInfo{Name: "CONS", Config: api.ConsumerConfig{Durable: "CONS"}})
+	assertListIsEmpty(t, check.Criticals)
+	assertListEquals(t, check.OKs, "Durable consumer")
+
+	check = &monitor.Result{}
+	cmd.checkConsumerDurable(check, api.ConsumerInfo{Name: "CONS", Config: api.ConsumerConfig{InactiveThreshold: 5 * time.Second}})
+	assertListIsEmpty(t, check.OKs)
+	assertListEquals(t, check.Criticals, "Ephemeral consumer")
+}
+
+func TestCheckConsumerExists(t *testing.T) {
+	withJetStream(t, func(_ *server.Server, _ *nats.Conn, mgr *jsm.Manager) {
+		cmd := &SrvCheckCmd{sourcesStream: "ORDERS", consumerName: "PROCESSOR", consumerExists: true}
+
+		t.Run("no stream", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkConsumerExists(check, mgr))
+			assertListIsEmpty(t, check.OKs)
+			assertListEquals(t, check.Criticals, "consumer PROCESSOR not found on stream ORDERS")
+		})
+
+		stream, err := mgr.NewStream("ORDERS", jsm.Subjects("orders.>"), jsm.MemoryStorage())
+		checkErr(t, err, "stream create failed: %v", err)
+
+		t.Run("no consumer", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkConsumerExists(check, mgr))
+			assertListIsEmpty(t, check.OKs)
+			assertListEquals(t, check.Criticals, "consumer PROCESSOR not found on stream ORDERS")
+		})
+
+		_, err = stream.NewConsumer(jsm.DurableName("PROCESSOR"))
+		checkErr(t, err, "consumer create failed: %v", err)
+
+		t.Run("exists", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkConsumerExists(check, mgr))
+			assertListIsEmpty(t, check.Criticals)
+			assertListEquals(t, check.OKs, "consumer PROCESSOR exists on stream ORDERS")
+		})
+	})
+}
+
+func TestCheckConsumerPaused(t *testing.T) {
+	until := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	paused := api.ConsumerInfo{Paused: true, PauseRemaining: 10 * time.Minute, Config: api.ConsumerConfig{PauseUntil: until}}
+
+	t.Run("unexpectedly paused", func(t *testing.T) {
+		cmd := &SrvCheckCmd{consumerPausedIsSet: true}
+		check := &monitor.Result{}
+		cmd.checkConsumerPaused(check, paused)
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "Paused until 2024-05-01T10:30:00Z")
+		assertHasPDItem(t, check, "pause_remaining=600.0000s")
+	})
+
+	t.Run("not paused", func(t *testing.T) {
+		cmd := &SrvCheckCmd{consumerPausedIsSet: true}
+		check := &monitor.Result{}
+		cmd.checkConsumerPaused(check, api.ConsumerInfo{})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "Not paused")
+	})
+
+	t.Run("expected pause", func(t *testing.T) {
+		cmd := &SrvCheckCmd{consumerPaused: true, consumerPausedIsSet: true, consumerPauseRemainingWarn: 5 * time.Minute}
+		check := &monitor.Result{}
+		cmd.checkConsumerPaused(check, paused)
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "Paused until 2024-05-01T10:30:00Z")
+
+		check = &monitor.Result{}
+		cmd.checkConsumerPaused(check, api.ConsumerInfo{})
+		assertListEquals(t, check.Criticals, "Not paused")
+	})
+
+	t.Run("pause expiring", func(t *testing.T) {
+		cmd := &SrvCheckCmd{consumerPaused: true, consumerPausedIsSet: true, consumerPauseRemainingWarn: 15 * time.Minute}
+		check := &monitor.Result{}
+		cmd.checkConsumerPaused(check, paused)
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "Resuming in 10m0s")
+	})
+}
+
+func TestCheckConsumerPlacement(t *testing.T) {
+	cmd := &SrvCheckCmd{}
+	stream := &api.ClusterInfo{Leader: "n1", Replicas: []*api.PeerInfo{{Name: "n2"}, {Name: "n3"}}}
+
+	t.Run("not clustered", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerPlacement(check, stream, nil)
+		assertListEquals(t, check.Criticals, "no cluster information")
+	})
+
+	t.Run("colocated", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerPlacement(check, stream, &api.ClusterInfo{Leader: "n3", Replicas: []*api.PeerInfo{{Name: "n1"}, {Name: "n2"}}})
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "3 consumer peers placed with the stream")
+		assertHasPDItem(t, check, "peer_overlap=3")
+	})
+
+	t.Run("diverged", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConsumerPlacement(check, stream, &api.ClusterInfo{Leader: "n4", Replicas: []*api.PeerInfo{{Name: "n1"}, {Name: "n2"}}})
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Warnings, "1 consumer peers not on stream peers: n4")
+		assertHasPDItem(t, check, "peer_overlap=2")
+	})
+}
+
+func TestCheckStreamConsumers(t *testing.T) {
+	cmd := &SrvCheckCmd{sourcesStream: "TEST", consumerAckOutstandingCritical: 100, useMetadata: true}
+
+	t.Run("healthy", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkStreamConsumers(check, []api.ConsumerInfo{
+			{Name: "C1", NumAckPending: 10},
+			{Name: "C2", NumAckPending: 20},
+		}, nil))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "2 healthy consumers")
+		assertHasPDItem(t, check, "consumers=2", "consumers_healthy=2")
+	})
+
+	t.Run("unhealthy and missing", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkStreamConsumers(check, []api.ConsumerInfo{
+			{Name: "C1", NumAckPending: 10},
+			{Name: "C2", NumAckPending: 200},
+		}, []string{"C3"}))
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "2 unhealthy consumers: C2, C3")
+		assertHasPDItem(t, check, "consumers=3", "consumers_healthy=1")
+	})
+
+	t.Run("metadata thresholds", func(t *testing.T) {
+		options.DefaultOptions = &options.Options{}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkStreamConsumers(check, []api.ConsumerInfo{
+			{Name: "C1", NumAckPending: 10, Config: api.ConsumerConfig{Metadata: map[string]string{"io.nats.monitor.outstanding-ack-critical": "5"}}},
+			{Name: "C2", NumAckPending: 10},
+		}, nil))
+		assertListEquals(t, check.Criticals, "1 unhealthy consumers: C1")
+		if cmd.consumerAckOutstandingCritical != 100 {
+			t.Fatalf("metadata thresholds leaked into the command: %d", cmd.consumerAckOutstandingCritical)
+		}
+	})
+}
+
 func TestCheckMessage(t *testing.T) {
 	t.Run("Body timestamp", func(t *testing.T) {
 		withJetStream(t, func(_ *server.Server, nc *nats.Conn, mgr *jsm.Manager) {
@@ -492,6 +773,22 @@ func TestCheckAccountInfo(t *testing.T) {
 		assertHasPDItem(t, check, "memory=128B memory_pct=12%;75;90 storage=1024B storage_pct=5%;75;90 streams=10 streams_pct=5% consumers=100 consumers_pct=10%")
 	})
 
+	t.Run("Tiers", func(t *testing.T) {
+		cmd, info := setDefaults()
+
+		info.Tiers = map[string]api.JetStreamTier{
+			"R3": {Memory: 64, ReservedMemory: 512, Store: 2048, ReservedStore: 4096, Streams: 2, Consumers: 4, Limits: api.JetStreamAccountLimits{MaxMemory: 128, MaxStore: 8192}},
+			"R1": {Memory: 64, Store: 1024, Streams: 8, Consumers: 96},
+		}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountInfo(check, info))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertHasPDItem(t, check,
+			"tier_memory[R1]=64B tier_memory_pct[R1]=0% tier_reserved_memory[R1]=0B tier_storage[R1]=1024B tier_storage_pct[R1]=0% tier_reserved_storage[R1]=0B tier_streams[R1]=8 tier_consumers[R1]=96 tier_memory[R3]",
+			"tier_memory[R3]=64B tier_memory_pct[R3]=50% tier_reserved_memory[R3]=512B tier_storage[R3]=2048B tier_storage_pct[R3]=25% tier_reserved_storage[R3]=4096B tier_streams[R3]=2 tier_consumers[R3]=4")
+	})
+
 	t.Run("Limits, Thresholds", func(t *testing.T) {
 		cmd, info := setDefaults()
 
@@ -538,110 +835,331 @@ func TestCheckAccountInfo(t *testing.T) {
 	})
 }
 
-func TestCheckMirror(t *testing.T) {
-	cmd := &SrvCheckCmd{}
-	info := &api.StreamInfo{}
-	cmd.sourcesSeenCritical = time.Second
-	cmd.sourcesLagCritical = 50
+func TestCheckStreamCreated(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 30, 0, 123456, time.UTC)
 
-	t.Run("no mirror", func(t *testing.T) {
+	t.Run("no expectation", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
 		check := &monitor.Result{}
-		assertNoError(t, cmd.checkMirror(check, info))
-		assertListEquals(t, check.Criticals, "not mirrored")
-		assertListIsEmpty(t, check.Warnings)
+		assertNoError(t, cmd.checkStreamCreated(check, created))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.OKs)
+		assertHasPDItem(t, check, fmt.Sprintf("created=%d", created.Unix()))
 	})
 
-	t.Run("failed mirror", func(t *testing.T) {
-		info.Mirror = &api.StreamSourceInfo{Name: "M", Lag: 100, Active: time.Hour}
-		check := &monitor.Result{}
-		err := cmd.checkMirror(check, info)
-		checkErr(t, err, "unexpected error")
-		assertHasPDItem(t, check, "lag=100;;50 active=3600.0000s;;1.0000")
-		assertListEquals(t, check.Criticals, "100 messages behind", "last active 1h0m0s")
-		assertListIsEmpty(t, check.Warnings)
+	t.Run("invalid time", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamCreated: "yesterday"}
+		err := cmd.checkStreamCreated(&monitor.Result{}, created)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
 	})
 
-	t.Run("ok mirror", func(t *testing.T) {
-		info.Mirror = &api.StreamSourceInfo{Name: "M", Lag: 1, Active: 10 * time.Millisecond}
+	t.Run("unchanged", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamCreated: "2024-05-01T10:30:00Z"}
 		check := &monitor.Result{}
-		assertNoError(t, cmd.checkMirror(check, info))
-		assertHasPDItem(t, check, "lag=1;;50 active=0.0100s;;1.0000")
+		assertNoError(t, cmd.checkStreamCreated(check, created))
 		assertListIsEmpty(t, check.Criticals)
-		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "created 2024-05-01T10:30:00Z")
+	})
+
+	t.Run("recreated", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamCreated: "2024-05-01T10:30:00Z"}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkStreamCreated(check, created.Add(time.Hour)))
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "created 2024-05-01T11:30:00Z expected 2024-05-01T10:30:00Z")
 	})
 }
 
-func TestCheckSources(t *testing.T) {
-	cmd := &SrvCheckCmd{}
-	info := &api.StreamInfo{}
+func TestCheckStreamDirect(t *testing.T) {
+	t.Run("not checked", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		check := &monitor.Result{}
+		cmd.checkStreamDirect(check, &api.StreamConfig{AllowDirect: true})
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.OKs)
+	})
 
-	t.Run("no sources", func(t *testing.T) {
+	t.Run("matching", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamAllowDirect: true, streamAllowDirectIsSet: true, streamMirrorDirectIsSet: true}
 		check := &monitor.Result{}
-		assertNoError(t, cmd.checkSources(check, info))
-		assertListEquals(t, check.Criticals, "no sources defined")
+		cmd.checkStreamDirect(check, &api.StreamConfig{AllowDirect: true})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "allow direct true", "mirror direct false")
 	})
 
-	cmd.sourcesLagCritical = 10
-	cmd.sourcesSeenCritical = time.Second
-	cmd.sourcesMaxSources = 10
-	cmd.sourcesMinSources = 1
+	t.Run("mismatch", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamAllowDirect: true, streamAllowDirectIsSet: true, streamMirrorDirect: true, streamMirrorDirectIsSet: true}
+		check := &monitor.Result{}
+		cmd.checkStreamDirect(check, &api.StreamConfig{})
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "allow direct false expected true", "mirror direct false expected true")
+	})
+}
 
-	t.Run("lagged source", func(t *testing.T) {
-		info = &api.StreamInfo{
-			Sources: []*api.StreamSourceInfo{
-				{Name: "s1", Lag: 1000, Active: time.Millisecond},
-			},
-		}
+func TestCheckMetadata(t *testing.T) {
+	cmd := &SrvCheckCmd{expectMetadata: map[string]string{"owner": "payments", "tier": "gold"}}
 
+	t.Run("matching", func(t *testing.T) {
 		check := &monitor.Result{}
-		assertNoError(t, cmd.checkSources(check, info))
-		assertListEquals(t, check.Criticals, "1 lagged sources")
-		assertHasPDItem(t, check, "sources=1;1;10", "sources_lagged=1", "sources_inactive=0")
+		cmd.checkMetadata(check, map[string]string{"owner": "payments", "tier": "gold", "other": "x"})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "2 metadata keys match")
 	})
 
-	t.Run("inactive source", func(t *testing.T) {
-		info = &api.StreamInfo{
-			Sources: []*api.StreamSourceInfo{
-				{Name: "s1", Active: time.Hour},
-			},
-		}
+	t.Run("mismatched", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkMetadata(check, map[string]string{"tier": "silver"})
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "2 metadata mismatches: owner missing, tier=silver")
+	})
 
+	t.Run("no metadata", func(t *testing.T) {
 		check := &monitor.Result{}
-		assertNoError(t, cmd.checkSources(check, info))
-		assertListEquals(t, check.Criticals, "1 inactive sources")
-		assertHasPDItem(t, check, "sources=1;1;10", "sources_lagged=0", "sources_inactive=1")
+		cmd.checkMetadata(check, nil)
+		assertListEquals(t, check.Criticals, "2 metadata mismatches: owner missing, tier missing")
 	})
+}
 
-	t.Run("not enough sources", func(t *testing.T) {
-		info = &api.StreamInfo{
-			Sources: []*api.StreamSourceInfo{
-				{Name: "s1", Active: time.Millisecond},
-			},
-		}
+func TestCheckStreamStorage(t *testing.T) {
+	t.Run("not checked", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		check := &monitor.Result{}
+		cmd.checkStreamStorage(check, &api.StreamConfig{Storage: api.MemoryStorage})
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.OKs)
+	})
 
-		cmd.sourcesMinSources = 2
+	t.Run("matching", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamStorage: "file"}
+		check := &monitor.Result{}
+		cmd.checkStreamStorage(check, &api.StreamConfig{Storage: api.FileStorage})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "file storage")
+	})
 
+	t.Run("mismatch", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamStorage: "file"}
 		check := &monitor.Result{}
-		assertNoError(t, cmd.checkSources(check, info))
-		assertListEquals(t, check.Criticals, "1 sources of min expected 2")
-		assertHasPDItem(t, check, "sources=1;2;10", "sources_lagged=0", "sources_inactive=0")
+		cmd.checkStreamStorage(check, &api.StreamConfig{Storage: api.MemoryStorage})
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "memory storage expected file")
 	})
+}
 
-	t.Run("too many sources", func(t *testing.T) {
-		info = &api.StreamInfo{
-			Sources: []*api.StreamSourceInfo{
-				{Name: "s1", Active: time.Millisecond},
-				{Name: "s2", Active: time.Millisecond},
-			},
+func TestCheckStreamBytes(t *testing.T) {
+	cfg := &api.StreamConfig{MaxBytes: 1024}
+
+	t.Run("no thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamBytesPctWarn: -1, streamBytesPctCrit: -1}
+		check := &monitor.Result{}
+		cmd.checkStreamBytes(check, cfg, api.StreamState{Bytes: 1024})
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListIsEmpty(t, check.OKs)
+		if len(check.PerfData) != 0 {
+			t.Fatalf("expected no perfdata got %v", check.PerfData)
 		}
+	})
 
-		cmd.sourcesMinSources = 1
-		cmd.sourcesMaxSources = 1
+	cmd := &SrvCheckCmd{streamBytesPctWarn: 80, streamBytesPctCrit: 90}
 
+	t.Run("no limit", func(t *testing.T) {
 		check := &monitor.Result{}
-		assertNoError(t, cmd.checkSources(check, info))
-		assertListEquals(t, check.Criticals, "2 sources of max expected 1")
-		assertHasPDItem(t, check, "sources=2;1;1", "sources_lagged=0", "sources_inactive=0")
+		cmd.checkStreamBytes(check, &api.StreamConfig{MaxBytes: -1}, api.StreamState{Bytes: 1024})
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "no byte limit configured")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkStreamBytes(check, cfg, api.StreamState{Bytes: 512})
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertHasPDItem(t, check, "bytes_pct=50%;80;90")
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkStreamBytes(check, cfg, api.StreamState{Bytes: 850})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "83% of 1.0 KiB max bytes used")
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkStreamBytes(check, cfg, api.StreamState{Bytes: 1024})
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.Criticals, "100% of 1.0 KiB max bytes used")
+	})
+}
+
+func TestCheckStreamRollup(t *testing.T) {
+	t.Run("allow rollup", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamAllowRollup: true, streamAllowRollupIsSet: true}
+		check := &monitor.Result{}
+		cmd.checkStreamRollup(check, &api.StreamConfig{}, nil)
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "allow rollup false expected true")
+
+		check = &monitor.Result{}
+		cmd.checkStreamRollup(check, &api.StreamConfig{RollupAllowed: true}, nil)
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "allow rollup true")
+	})
+
+	t.Run("rollup subject", func(t *testing.T) {
+		cmd := &SrvCheckCmd{streamRollupSubject: "cfg.>"}
+
+		check := &monitor.Result{}
+		cmd.checkStreamRollup(check, &api.StreamConfig{}, nil)
+		assertListEquals(t, check.Criticals, "no messages on cfg.>")
+
+		check = &monitor.Result{}
+		cmd.checkStreamRollup(check, &api.StreamConfig{}, map[string]uint64{"cfg.a": 1, "cfg.b": 1})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "2 subjects rolled up")
+
+		check = &monitor.Result{}
+		cmd.checkStreamRollup(check, &api.StreamConfig{}, map[string]uint64{"cfg.a": 1, "cfg.c": 3, "cfg.b": 2})
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "2 subjects with multiple values: cfg.b, cfg.c")
+	})
+}
+
+func TestCheckMirror(t *testing.T) {
+	cmd := &SrvCheckCmd{}
+	info := &api.StreamInfo{}
+	cmd.sourcesSeenCritical = time.Second
+	cmd.sourcesLagCritical = 50
+
+	t.Run("no mirror", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkMirror(check, info))
+		assertListEquals(t, check.Criticals, "not mirrored")
+		assertListIsEmpty(t, check.Warnings)
+	})
+
+	t.Run("failed mirror", func(t *testing.T) {
+		info.Mirror = &api.StreamSourceInfo{Name: "M", Lag: 100, Active: time.Hour}
+		check := &monitor.Result{}
+		err := cmd.checkMirror(check, info)
+		checkErr(t, err, "unexpected error")
+		assertHasPDItem(t, check, "lag=100;;50 active=3600.0000s;;1.0000")
+		assertListEquals(t, check.Criticals, "100 messages behind", "last active 1h0m0s")
+		assertListIsEmpty(t, check.Warnings)
+	})
+
+	t.Run("ok mirror", func(t *testing.T) {
+		info.Mirror = &api.StreamSourceInfo{Name: "M", Lag: 1, Active: 10 * time.Millisecond}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkMirror(check, info))
+		assertHasPDItem(t, check, "lag=1;;50 active=0.0100s;;1.0000")
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+	})
+
+	t.Run("lagged mirror", func(t *testing.T) {
+		cmd := &SrvCheckCmd{sourcesLagWarn: 10, sourcesLagCritical: 50, sourcesSeenWarn: time.Second, sourcesSeenCritical: time.Minute}
+		info.Mirror = &api.StreamSourceInfo{Name: "M", Lag: 20, Active: 2 * time.Second}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkMirror(check, info))
+		assertHasPDItem(t, check, "lag=20;10;50 active=2.0000s;1.0000;60.0000")
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "20 messages behind", "last active 2s")
+	})
+}
+
+func TestCheckSources(t *testing.T) {
+	cmd := &SrvCheckCmd{}
+	info := &api.StreamInfo{}
+
+	t.Run("no sources", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkSources(check, info))
+		assertListEquals(t, check.Criticals, "no sources defined")
+	})
+
+	cmd.sourcesLagCritical = 10
+	cmd.sourcesSeenCritical = time.Second
+	cmd.sourcesMaxSources = 10
+	cmd.sourcesMinSources = 1
+
+	t.Run("lagged source", func(t *testing.T) {
+		info = &api.StreamInfo{
+			Sources: []*api.StreamSourceInfo{
+				{Name: "s1", Lag: 1000, Active: time.Millisecond},
+			},
+		}
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkSources(check, info))
+		assertListEquals(t, check.Criticals, "1 lagged sources")
+		assertHasPDItem(t, check, "sources=1;1;10", "sources_lagged=1", "sources_inactive=0")
+	})
+
+	t.Run("inactive source", func(t *testing.T) {
+		info = &api.StreamInfo{
+			Sources: []*api.StreamSourceInfo{
+				{Name: "s1", Active: time.Hour},
+			},
+		}
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkSources(check, info))
+		assertListEquals(t, check.Criticals, "1 inactive sources")
+		assertHasPDItem(t, check, "sources=1;1;10", "sources_lagged=0", "sources_inactive=1")
+	})
+
+	t.Run("not enough sources", func(t *testing.T) {
+		info = &api.StreamInfo{
+			Sources: []*api.StreamSourceInfo{
+				{Name: "s1", Active: time.Millisecond},
+			},
+		}
+
+		cmd.sourcesMinSources = 2
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkSources(check, info))
+		assertListEquals(t, check.Criticals, "1 sources of min expected 2")
+		assertHasPDItem(t, check, "sources=1;2;10", "sources_lagged=0", "sources_inactive=0")
+	})
+
+	t.Run("too many sources", func(t *testing.T) {
+		info = &api.StreamInfo{
+			Sources: []*api.StreamSourceInfo{
+				{Name: "s1", Active: time.Millisecond},
+				{Name: "s2", Active: time.Millisecond},
+			},
+		}
+
+		cmd.sourcesMinSources = 1
+		cmd.sourcesMaxSources = 1
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkSources(check, info))
+		assertListEquals(t, check.Criticals, "2 sources of max expected 1")
+		assertHasPDItem(t, check, "sources=2;1;1", "sources_lagged=0", "sources_inactive=0")
+	})
+
+	t.Run("warning thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{sourcesLagWarn: 10, sourcesLagCritical: 50, sourcesSeenWarn: time.Second, sourcesSeenCritical: time.Minute, sourcesMinSources: 1, sourcesMaxSources: 10}
+		info = &api.StreamInfo{
+			Sources: []*api.StreamSourceInfo{
+				{Name: "s1", Lag: 20, Active: 2 * time.Second},
+				{Name: "s2", Lag: 100, Active: time.Millisecond},
+				{Name: "s3", Lag: 1, Active: time.Millisecond},
+			},
+		}
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkSources(check, info))
+		assertListEquals(t, check.Criticals, "1 lagged sources")
+		assertListEquals(t, check.Warnings, "1 lagged sources", "1 inactive sources")
+		assertHasPDItem(t, check, "sources_lagged=1", "sources_inactive=0")
 	})
 }
 
@@ -680,6 +1198,26 @@ func TestCheckVarz(t *testing.T) {
 		assertListEquals(t, check.OKs, "JetStream enabled")
 	})
 
+	t.Run("jetstream domain", func(t *testing.T) {
+		cmd := &SrvCheckCmd{srvName: "testing", srvJSDomain: "hub"}
+		vz := &server.Varz{Name: "testing"}
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListEquals(t, check.Criticals, "JetStream not enabled, expected domain hub")
+
+		vz.JetStream.Config = &server.JetStreamConfig{Domain: "leaf"}
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListEquals(t, check.Criticals, `JetStream domain "leaf" expected "hub"`)
+
+		vz.JetStream.Config.Domain = "hub"
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "JetStream domain hub")
+	})
+
 	t.Run("tls", func(t *testing.T) {
 		cmd := &SrvCheckCmd{srvName: "testing"}
 		cmd.srvTLSRequired = true
@@ -712,6 +1250,56 @@ func TestCheckVarz(t *testing.T) {
 		assertListEquals(t, check.OKs, "Authentication required")
 	})
 
+	t.Run("write deadline", func(t *testing.T) {
+		cmd := &SrvCheckCmd{srvName: "testing", srvWriteDeadline: 10 * time.Second}
+		vz := &server.Varz{Name: "testing", WriteDeadline: 2 * time.Second}
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListEquals(t, check.Criticals, "Write deadline 2s expected 10s")
+
+		vz.WriteDeadline = 10 * time.Second
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "Write deadline 10s")
+	})
+
+	t.Run("max pending", func(t *testing.T) {
+		cmd := &SrvCheckCmd{srvName: "testing", srvMaxPending: 64 * units.MiB}
+		vz := &server.Varz{Name: "testing", MaxPending: 32 * 1024 * 1024}
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListEquals(t, check.Criticals, "Max pending 32 MiB expected 64 MiB")
+
+		vz.MaxPending = 64 * 1024 * 1024
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "Max pending 64 MiB")
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		cmd := &SrvCheckCmd{srvName: "testing", srvWSTLS: true, srvWSOrigins: []string{"https://b.example.net", "https://a.example.net"}}
+		vz := &server.Varz{Name: "testing"}
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListEquals(t, check.Criticals, "WebSocket not enabled")
+
+		vz.Websocket = server.WebsocketOptsVarz{Port: 8080, NoTLS: true}
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListEquals(t, check.Criticals, "WebSocket TLS not required", "WebSocket allowed origins any expected https://a.example.net, https://b.example.net")
+
+		vz.Websocket = server.WebsocketOptsVarz{Port: 8080, AllowedOrigins: []string{"https://a.example.net", "https://b.example.net"}}
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkVarz(check, vz))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "WebSocket TLS required", "WebSocket allowed origins https://a.example.net, https://b.example.net")
+	})
+
 	t.Run("uptime", func(t *testing.T) {
 		cmd := &SrvCheckCmd{srvName: "testing"}
 		vz := &server.Varz{Name: "testing"}
@@ -1010,6 +1598,23 @@ SUAKYITMHPMSYUGPNQBLLPGOPFQN44XNCGXHNSHLJJVMD3IKYGBOLAI7TI
 		assertListIsEmpty(t, check.OKs)
 	})
 
+	t.Run("environment", func(t *testing.T) {
+		cred := writeCred(t, expires2100)
+		defer os.Remove(cred)
+
+		t.Setenv("NATS_CREDS_FILE", cred)
+
+		cmd := &SrvCheckCmd{credential: "$NATS_CREDS_FILE"}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkCredential(check))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "expires in 2100-01-01 00:00:00 +0000 UTC")
+
+		t.Setenv("NATS_CREDS_FILE", "")
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkCredential(check))
+		assertListEquals(t, check.Criticals, `credential path "$NATS_CREDS_FILE" expanded to an empty value`)
+	})
 }
 func TestCheckJSZ(t *testing.T) {
 	cmd := &SrvCheckCmd{}
@@ -1139,3 +1744,1786 @@ func TestCheckJSZ(t *testing.T) {
 			"1 lagged more than 10 ops")
 	})
 }
+
+func TestCheckMetaClusterInfo(t *testing.T) {
+	cmd := &SrvCheckCmd{raftExpect: 3, raftSeenCritical: time.Second, raftLagCritical: 10}
+	meta := &server.MetaClusterInfo{
+		Leader: "n1",
+		Replicas: []*server.PeerInfo{
+			{Name: "n2", Current: true, Active: 10 * time.Millisecond, Lag: 1},
+			{Name: "nats-3.example.net", Offline: true, Active: 10 * time.Hour, Lag: 10000},
+		},
+	}
+
+	check := &monitor.Result{}
+	assertNoError(t, cmd.checkMetaClusterInfo(check, meta))
+	assertListEquals(t, check.Criticals, "1 not current", "1 inactive more than 1s", "1 offline", "1 lagged more than 10 ops")
+	assertHasPDItem(t, check,
+		"replica_current[n2]=1 replica_offline[n2]=0 replica_lag[n2]=1 replica_active[n2]=0.0100s",
+		"replica_current[nats-3.example.net]=0 replica_offline[nats-3.example.net]=1 replica_lag[nats-3.example.net]=10000 replica_active[nats-3.example.net]=36000.0000s")
+
+	t.Run("similar peer names", func(t *testing.T) {
+		cmd := &SrvCheckCmd{raftExpect: 3, raftSeenCritical: time.Second, raftLagCritical: 10}
+		meta := &server.MetaClusterInfo{
+			Leader: "n1",
+			Replicas: []*server.PeerInfo{
+				{Name: "nats-3.example.net", Current: true, Lag: 1},
+				{Name: "nats_3_example_net", Current: true, Lag: 2},
+			},
+		}
+
+		check := &monitor.Result{Name: "JetStream Meta Cluster", Check: "meta", NameSpace: "nats", RenderFormat: monitor.PrometheusFormat}
+		assertNoError(t, cmd.checkMetaClusterInfo(check, meta))
+
+		out := check.String()
+		for _, expected := range []string{
+			`nats_meta_replica_lag{item="nats-3.example.net"} 1`,
+			`nats_meta_replica_lag{item="nats_3_example_net"} 2`,
+		} {
+			if !strings.Contains(out, expected) {
+				t.Fatalf("expected %q in output:\n%s", expected, out)
+			}
+		}
+	})
+}
+
+func TestCheckPeerLagSpread(t *testing.T) {
+	cmd := &SrvCheckCmd{raftSpreadWarn: 10, raftSpreadCrit: 100}
+
+	t.Run("no leader", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkPeerLagSpread(check, &server.ClusterInfo{})
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		if len(check.PerfData) != 0 {
+			t.Fatalf("expected no perf data: %s", check.PerfData)
+		}
+	})
+
+	t.Run("within thresholds", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkPeerLagSpread(check, &server.ClusterInfo{
+			Leader: "l1",
+			Replicas: []*server.PeerInfo{
+				{Name: "replica1", Lag: 1},
+				{Name: "replica2", Lag: 5},
+			},
+		})
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertHasPDItem(t, check, "peer_max_lag=5;10;100")
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkPeerLagSpread(check, &server.ClusterInfo{
+			Leader: "l1",
+			Replicas: []*server.PeerInfo{
+				{Name: "replica1", Lag: 1},
+				{Name: "replica2", Lag: 50},
+			},
+		})
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "peer lag spread 50 ops")
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkPeerLagSpread(check, &server.ClusterInfo{
+			Leader: "l1",
+			Replicas: []*server.PeerInfo{
+				{Name: "replica1", Lag: 1000},
+				{Name: "replica2", Lag: 1},
+			},
+		})
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.Criticals, "peer lag spread 1000 ops")
+	})
+}
+
+func TestCheckNaming(t *testing.T) {
+	cmd := &SrvCheckCmd{namingViolationsWarn: 1, namingViolationsCrit: 3}
+	pattern := regexp.MustCompile(`^[A-Z]+_[A-Z]+$`)
+
+	t.Run("conforming", func(t *testing.T) {
+		check := &monitor.Result{}
+		bad := cmd.nonConformingNames(pattern, []string{"ORDERS_NEW", "ORDERS_DONE"})
+		cmd.checkNamingViolations(check, 2, bad)
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "2 names checked")
+		assertHasPDItem(t, check, "names=2", "violations=0;1;3")
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		check := &monitor.Result{}
+		bad := cmd.nonConformingNames(pattern, []string{"ORDERS_NEW", "orders"})
+		cmd.checkNamingViolations(check, 2, bad)
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "1 non-conforming names: orders")
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		check := &monitor.Result{}
+		bad := cmd.nonConformingNames(pattern, []string{"a", "b", "c", "ORDERS_NEW"})
+		cmd.checkNamingViolations(check, 4, bad)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.Criticals, "3 non-conforming names: a, b, c")
+		assertHasPDItem(t, check, "names=4", "violations=3;1;3")
+	})
+}
+
+func TestCheckAssets(t *testing.T) {
+	cmd := &SrvCheckCmd{assetsWarn: 100, assetsCrit: 200}
+
+	t.Run("nil data", func(t *testing.T) {
+		check := &monitor.Result{}
+		err := cmd.checkAssets(check, nil)
+		if err.Error() != "no data received" {
+			t.Fatalf("expected no data error: %v", err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAssets(check, &server.JSInfo{Disabled: true}))
+		assertListEquals(t, check.Criticals, "JetStream not enabled")
+	})
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{assetsWarn: 200, assetsCrit: 100}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAssets(check, &server.JSInfo{Streams: 1}))
+		assertListEquals(t, check.Criticals, "assets: invalid thresholds")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAssets(check, &server.JSInfo{Streams: 10, Consumers: 20}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "30 assets")
+		assertHasPDItem(t, check, "assets=30;100;200", "streams=10", "consumers=20")
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAssets(check, &server.JSInfo{Streams: 50, Consumers: 100}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "150 assets")
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAssets(check, &server.JSInfo{Streams: 50, Consumers: 150}))
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.Criticals, "200 assets")
+	})
+}
+
+func TestCheckAccountThroughput(t *testing.T) {
+	cmd := &SrvCheckCmd{
+		throughputInMsgsWarn: 100, throughputInMsgsCrit: 200,
+		throughputInBytesWarn: -1, throughputInBytesCrit: -1,
+		throughputOutMsgsWarn: -1, throughputOutMsgsCrit: -1,
+		throughputOutBytesWarn: -1, throughputOutBytesCrit: -1,
+	}
+
+	now := time.Now()
+	sample := func(offset time.Duration, in int64, out int64) *accountThroughputSample {
+		return &accountThroughputSample{
+			time:     now.Add(offset),
+			received: server.DataStats{Msgs: in, Bytes: in * 10},
+			sent:     server.DataStats{Msgs: out, Bytes: out * 10},
+		}
+	}
+
+	t.Run("no data", func(t *testing.T) {
+		check := &monitor.Result{}
+		err := cmd.checkAccountThroughput(check, nil, nil)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountThroughput(check, sample(0, 100, 100), sample(10*time.Second, 600, 1100)))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "50.00 msgs/s in 100.00 msgs/s out")
+		assertHasPDItem(t, check, "in_msgs_rate=50;100;200", "in_bytes_rate=500", "out_msgs_rate=100", "out_bytes_rate=1000")
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountThroughput(check, sample(0, 0, 0), sample(10*time.Second, 1500, 0)))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "in_msgs_rate 150.00/s")
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountThroughput(check, sample(0, 0, 0), sample(10*time.Second, 2500, 0)))
+		assertListEquals(t, check.Criticals, "in_msgs_rate 250.00/s")
+	})
+
+	t.Run("counter reset", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountThroughput(check, sample(0, 5000, 5000), sample(10*time.Second, 10, 10)))
+		assertListIsEmpty(t, check.Criticals)
+		assertHasPDItem(t, check, "in_msgs_rate=0;100;200")
+	})
+}
+
+func TestCheckMessageRate(t *testing.T) {
+	now := time.Now()
+	sample := func(offset time.Duration, seq uint64) *streamSequenceSample {
+		return &streamSequenceSample{time: now.Add(offset), lastSeq: seq}
+	}
+
+	t.Run("no data", func(t *testing.T) {
+		cmd := &SrvCheckCmd{msgRateMinWarn: -1, msgRateMinCrit: -1, msgRateMaxWarn: -1, msgRateMaxCrit: -1}
+		err := cmd.checkMessageRate(&monitor.Result{}, nil, nil)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{msgRateMinWarn: 1, msgRateMinCrit: 2, msgRateMaxWarn: -1, msgRateMaxCrit: -1}
+		err := cmd.checkMessageRate(&monitor.Result{}, sample(0, 0), sample(time.Second, 1))
+		if err == nil || err.Error() != "invalid thresholds" {
+			t.Fatalf("expected invalid thresholds error: %v", err)
+		}
+
+		cmd = &SrvCheckCmd{msgRateMinWarn: -1, msgRateMinCrit: -1, msgRateMaxWarn: 20, msgRateMaxCrit: 10}
+		err = cmd.checkMessageRate(&monitor.Result{}, sample(0, 0), sample(time.Second, 1))
+		if err == nil || err.Error() != "invalid thresholds" {
+			t.Fatalf("expected invalid thresholds error: %v", err)
+		}
+	})
+
+	cmd := &SrvCheckCmd{msgRateMinWarn: 10, msgRateMinCrit: 0, msgRateMaxWarn: 100, msgRateMaxCrit: 200}
+
+	t.Run("ok", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkMessageRate(check, sample(0, 100), sample(10*time.Second, 600)))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "50.00 msgs/s")
+		assertHasPDItem(t, check, "msgs_rate=50;100;200")
+	})
+
+	t.Run("stuck", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkMessageRate(check, sample(0, 100), sample(10*time.Second, 100)))
+		assertListEquals(t, check.Criticals, "0.00 msgs/s")
+
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkMessageRate(check, sample(0, 100), sample(10*time.Second, 150)))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "5.00 msgs/s")
+	})
+
+	t.Run("spike", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkMessageRate(check, sample(0, 0), sample(10*time.Second, 1500)))
+		assertListEquals(t, check.Warnings, "150.00 msgs/s")
+
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkMessageRate(check, sample(0, 0), sample(10*time.Second, 2500)))
+		assertListEquals(t, check.Criticals, "250.00 msgs/s")
+	})
+
+	t.Run("recreated", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkMessageRate(check, sample(0, 5000), sample(10*time.Second, 10)))
+		assertListEquals(t, check.Criticals, "0.00 msgs/s")
+	})
+}
+
+func TestCheckRequest(t *testing.T) {
+	withJetStream(t, func(_ *server.Server, nc *nats.Conn, _ *jsm.Manager) {
+		opts().Timeout = time.Second
+		cmd := &SrvCheckCmd{reqSubject: "service", reqWarning: 500 * time.Millisecond, reqCritical: time.Second}
+
+		t.Run("no responders", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkRequest(check, nc))
+			assertListIsEmpty(t, check.OKs)
+			assertListEquals(t, check.Criticals, "no responders on service")
+		})
+
+		sub, err := nc.Subscribe("service", func(m *nats.Msg) {
+			m.Respond([]byte("ok"))
+		})
+		checkErr(t, err, "subscribe failed: %v", err)
+		defer sub.Unsubscribe()
+
+		t.Run("response", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkRequest(check, nc))
+			assertListIsEmpty(t, check.Criticals)
+			assertListIsEmpty(t, check.Warnings)
+			if len(check.OKs) != 1 {
+				t.Fatalf("expected 1 ok got: %v", check.OKs)
+			}
+			assertHasPDItem(t, check, "request_time=")
+		})
+
+		t.Run("content", func(t *testing.T) {
+			cmd := &SrvCheckCmd{reqSubject: "service", msgRegexp: regexp.MustCompile("^ok$")}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkRequest(check, nc))
+			assertListIsEmpty(t, check.Criticals)
+			if len(check.OKs) != 1 {
+				t.Fatalf("expected 1 ok got: %v", check.OKs)
+			}
+
+			cmd.msgRegexp = regexp.MustCompile("^failed$")
+			check = &monitor.Result{}
+			assertNoError(t, cmd.checkRequest(check, nc))
+			assertListIsEmpty(t, check.OKs)
+			assertListEquals(t, check.Criticals, "response does not match regex: ^failed$")
+		})
+	})
+}
+
+func TestRTTAtPercentile(t *testing.T) {
+	var samples []time.Duration
+	for i := 20; i > 0; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	for _, tc := range []struct {
+		percentile int
+		expected   time.Duration
+	}{
+		{100, 20 * time.Millisecond},
+		{95, 19 * time.Millisecond},
+		{50, 10 * time.Millisecond},
+		{1, time.Millisecond},
+	} {
+		cmd := &SrvCheckCmd{rttPercentile: tc.percentile}
+		rtt, err := cmd.rttAtPercentile(samples)
+		assertNoError(t, err)
+		if rtt != tc.expected {
+			t.Fatalf("p%d expected %v got %v", tc.percentile, tc.expected, rtt)
+		}
+	}
+
+	cmd := &SrvCheckCmd{rttPercentile: 100}
+	rtt, err := cmd.rttAtPercentile([]time.Duration{time.Second})
+	assertNoError(t, err)
+	if rtt != time.Second {
+		t.Fatalf("expected 1s got %v", rtt)
+	}
+
+	_, err = cmd.rttAtPercentile(nil)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	cmd.rttPercentile = 0
+	_, err = cmd.rttAtPercentile(samples)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestCheckConnectionRTT(t *testing.T) {
+	cmd := &SrvCheckCmd{rttWarning: 500 * time.Millisecond, rttCritical: time.Second}
+
+	t.Run("ok", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConnectionRTT(check, 10*time.Millisecond)
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "rtt time 10ms")
+		assertHasPDItem(t, check, "rtt=0.0100s;0.5000;1.0000")
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConnectionRTT(check, 600*time.Millisecond)
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Warnings, "rtt time exceeded 500ms")
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		check := &monitor.Result{}
+		cmd.checkConnectionRTT(check, 2*time.Second)
+		assertListIsEmpty(t, check.Warnings)
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "rtt time exceeded 1s")
+	})
+}
+
+func TestCheckRoutes(t *testing.T) {
+	hosts := map[string][]string{
+		"n1.example.net": {"10.0.0.1"},
+		"n2.example.net": {"10.0.0.2"},
+		"n3.example.net": {"10.0.0.3"},
+		"10.0.0.4":       {"10.0.0.4"},
+	}
+	resolve := func(host string) ([]string, error) {
+		ips, ok := hosts[host]
+		if !ok {
+			return nil, fmt.Errorf("no such host")
+		}
+		return ips, nil
+	}
+
+	vz := &server.Varz{Cluster: server.ClusterOptsVarz{
+		Host: "10.0.0.1",
+		Port: 6222,
+		URLs: []string{"nats://n1.example.net:6222", "nats://n2.example.net:6222", "nats://n3.example.net:6222", "nats://10.0.0.4:6222"},
+	}}
+
+	t.Run("nil data", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		err := cmd.checkRoutes(&monitor.Result{}, nil, nil, resolve)
+		if err.Error() != "no data received" {
+			t.Fatalf("expected no data error: %v", err)
+		}
+	})
+
+	t.Run("all connected", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		rz := &server.Routez{NumRoutes: 3, Routes: []*server.RouteInfo{
+			{RemoteID: "N2", DidSolicit: true, IP: "10.0.0.2", Port: 6222},
+			{RemoteID: "N3", DidSolicit: true, IP: "10.0.0.3", Port: 6222},
+			{RemoteID: "N4", DidSolicit: true, IP: "10.0.0.4", Port: 6222},
+		}}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkRoutes(check, vz, rz, resolve))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "3 configured routes connected")
+		assertHasPDItem(t, check, "configured_routes=3", "connected_routes=3", "routes=3")
+	})
+
+	t.Run("solicited by the remote", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		rz := &server.Routez{NumRoutes: 3, Routes: []*server.RouteInfo{
+			{RemoteID: "N2", DidSolicit: true, IP: "10.0.0.2", Port: 6222},
+			{RemoteID: "N3", DidSolicit: false, IP: "10.0.0.3", Port: 51234},
+			{RemoteID: "N4", DidSolicit: false, IP: "10.0.0.4", Port: 48211},
+		}}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkRoutes(check, vz, rz, resolve))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "3 configured routes connected")
+		assertHasPDItem(t, check, "connected_routes=3")
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		rz := &server.Routez{NumRoutes: 2, Routes: []*server.RouteInfo{
+			{RemoteID: "N2", DidSolicit: true, IP: "10.0.0.2", Port: 6222},
+			{RemoteID: "N3", DidSolicit: false, IP: "10.0.0.3", Port: 51234},
+		}}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkRoutes(check, vz, rz, resolve))
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "1 of 3 configured routes not connected: nats://10.0.0.4:6222")
+		assertHasPDItem(t, check, "connected_routes=2")
+	})
+
+	t.Run("shared host", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		vz := &server.Varz{Cluster: server.ClusterOptsVarz{
+			Host: "10.0.0.1",
+			Port: 6222,
+			URLs: []string{"nats://10.0.0.4:6222", "nats://10.0.0.4:6223"},
+		}}
+		rz := &server.Routez{NumRoutes: 3, Routes: []*server.RouteInfo{
+			{RemoteID: "N4", DidSolicit: false, IP: "10.0.0.4", Port: 48211},
+			{RemoteID: "N4", DidSolicit: false, IP: "10.0.0.4", Port: 48212},
+			{RemoteID: "N4", DidSolicit: false, IP: "10.0.0.4", Port: 48213},
+		}}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkRoutes(check, vz, rz, resolve))
+		assertListEquals(t, check.Criticals, "1 of 2 configured routes not connected: nats://10.0.0.4:6223")
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		cmd := &SrvCheckCmd{routesIgnore: []string{"nats://n3.example.net:6222", "nats://10.0.0.4:6222"}}
+		rz := &server.Routez{NumRoutes: 1, Routes: []*server.RouteInfo{{RemoteID: "N2", DidSolicit: true, IP: "10.0.0.2", Port: 6222}}}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkRoutes(check, vz, rz, resolve))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "1 configured routes connected")
+	})
+}
+
+func TestCheckLeafnodes(t *testing.T) {
+	lz := &server.Leafz{NumLeafs: 2, Leafs: []*server.LeafInfo{{Name: "edge1"}, {Name: "edge2"}}}
+
+	t.Run("nil data", func(t *testing.T) {
+		cmd := &SrvCheckCmd{leafsWarn: -1, leafsCrit: -1}
+		err := cmd.checkLeafnodes(&monitor.Result{}, nil)
+		if err.Error() != "no data received" {
+			t.Fatalf("expected no data error: %v", err)
+		}
+	})
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{leafsWarn: 1, leafsCrit: 2}
+		err := cmd.checkLeafnodes(&monitor.Result{}, lz)
+		if err.Error() != "invalid thresholds" {
+			t.Fatalf("expected invalid thresholds error: %v", err)
+		}
+	})
+
+	t.Run("connected", func(t *testing.T) {
+		cmd := &SrvCheckCmd{leafRemotes: []string{"edge1", "edge2"}, leafsWarn: 1, leafsCrit: 0}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkLeafnodes(check, lz))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "2 leafnodes connected")
+		assertHasPDItem(t, check, "leafnodes=2;1")
+	})
+
+	t.Run("thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{leafsWarn: 2, leafsCrit: 2}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkLeafnodes(check, lz))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "2 leafnodes connected")
+
+		cmd = &SrvCheckCmd{leafsWarn: 3, leafsCrit: 1}
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkLeafnodes(check, lz))
+		assertListEquals(t, check.Warnings, "2 leafnodes connected")
+
+		cmd = &SrvCheckCmd{leafsWarn: 4, leafsCrit: 3}
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkLeafnodes(check, lz))
+		assertListEquals(t, check.Criticals, "2 leafnodes connected")
+	})
+
+	t.Run("missing remote", func(t *testing.T) {
+		cmd := &SrvCheckCmd{leafRemotes: []string{"edge1", "edge3"}, leafsWarn: -1, leafsCrit: -1}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkLeafnodes(check, lz))
+		assertListEquals(t, check.Criticals, "1 of 2 leafnodes not connected: edge3")
+	})
+}
+
+func TestCheckGateways(t *testing.T) {
+	gwz := &server.Gatewayz{
+		OutboundGateways: map[string]*server.RemoteGatewayz{
+			"east": {IsConfigured: true, Connection: &server.ConnInfo{}},
+			"west": {IsConfigured: true, Connection: &server.ConnInfo{}},
+		},
+		InboundGateways: map[string][]*server.RemoteGatewayz{
+			"east": {{Connection: &server.ConnInfo{}}},
+			"west": {{Connection: &server.ConnInfo{}}, {Connection: &server.ConnInfo{}}},
+		},
+	}
+
+	t.Run("nil data", func(t *testing.T) {
+		cmd := &SrvCheckCmd{gwOutboundWarn: -1, gwOutboundCrit: -1, gwInboundWarn: -1, gwInboundCrit: -1}
+		err := cmd.checkGateways(&monitor.Result{}, nil)
+		if err.Error() != "no data received" {
+			t.Fatalf("expected no data error: %v", err)
+		}
+	})
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{gwOutboundWarn: 1, gwOutboundCrit: 2, gwInboundWarn: -1, gwInboundCrit: -1}
+		err := cmd.checkGateways(&monitor.Result{}, gwz)
+		if err.Error() != "invalid thresholds" {
+			t.Fatalf("expected invalid thresholds error: %v", err)
+		}
+	})
+
+	t.Run("connected", func(t *testing.T) {
+		cmd := &SrvCheckCmd{gwRemotes: []string{"east", "west"}, gwOutboundWarn: 1, gwOutboundCrit: 0, gwInboundWarn: -1, gwInboundCrit: -1}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkGateways(check, gwz))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "2 outbound 3 inbound gateways")
+		assertHasPDItem(t, check, "outbound_gateways=2;1", "inbound_gateways=3")
+	})
+
+	t.Run("thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{gwOutboundWarn: 2, gwOutboundCrit: 2, gwInboundWarn: 3, gwInboundCrit: 3}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkGateways(check, gwz))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "2 outbound 3 inbound gateways")
+
+		cmd = &SrvCheckCmd{gwOutboundWarn: 3, gwOutboundCrit: 1, gwInboundWarn: -1, gwInboundCrit: 4}
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkGateways(check, gwz))
+		assertListEquals(t, check.Warnings, "2 outbound gateways")
+		assertListEquals(t, check.Criticals, "3 inbound gateways")
+	})
+
+	t.Run("disconnected", func(t *testing.T) {
+		gwz := &server.Gatewayz{OutboundGateways: map[string]*server.RemoteGatewayz{
+			"east": {IsConfigured: true, Connection: &server.ConnInfo{}},
+			"west": {IsConfigured: true},
+		}}
+		cmd := &SrvCheckCmd{gwRemotes: []string{"west", "north"}, gwOutboundWarn: -1, gwOutboundCrit: -1, gwInboundWarn: -1, gwInboundCrit: -1}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkGateways(check, gwz))
+		assertListEquals(t, check.Criticals, "2 gateways not connected: north, west")
+	})
+}
+
+func TestCheckAvailability(t *testing.T) {
+	flaky := func(every int) func() error {
+		attempt := 0
+		return func() error {
+			attempt++
+			if attempt%every == 0 {
+				return fmt.Errorf("stream not found")
+			}
+			return nil
+		}
+	}
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{availAttempts: 1, availWarn: 50, availCrit: 90}
+		err := cmd.checkAvailability(&monitor.Result{}, flaky(2))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("available", func(t *testing.T) {
+		cmd := &SrvCheckCmd{availAttempts: 5, availWarn: -1, availCrit: 100}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAvailability(check, func() error { return nil }))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "100% available over 5 attempts")
+		assertHasPDItem(t, check, "availability=100%;-1;100")
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		cmd := &SrvCheckCmd{availAttempts: 5, availWarn: 100, availCrit: 50}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAvailability(check, flaky(5)))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "80% available over 5 attempts, last error: stream not found")
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		cmd := &SrvCheckCmd{availAttempts: 4, availWarn: 100, availCrit: 75}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAvailability(check, flaky(2)))
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.Criticals, "50% available over 4 attempts, last error: stream not found")
+	})
+}
+
+func TestCheckHandshakes(t *testing.T) {
+	withJetStream(t, func(srv *server.Server, _ *nats.Conn, _ *jsm.Manager) {
+		connect := func() (*nats.Conn, error) {
+			return nats.Connect(srv.ClientURL())
+		}
+
+		t.Run("invalid thresholds", func(t *testing.T) {
+			cmd := &SrvCheckCmd{hsAttempts: 1, hsFailWarn: 50, hsFailCrit: 10}
+			err := cmd.checkHandshakes(&monitor.Result{}, connect)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+
+		t.Run("successful", func(t *testing.T) {
+			cmd := &SrvCheckCmd{hsAttempts: 3, hsSlow: time.Second, hsFailWarn: -1, hsFailCrit: 0}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkHandshakes(check, connect))
+			assertListIsEmpty(t, check.Criticals)
+			if len(check.OKs) != 1 || !strings.HasPrefix(check.OKs[0], "0 of 3 handshakes failed") {
+				t.Fatalf("unexpected oks: %v", check.OKs)
+			}
+			assertHasPDItem(t, check, "handshake_success=100%", "handshake_time=")
+		})
+
+		t.Run("failures", func(t *testing.T) {
+			attempt := 0
+			flaky := func() (*nats.Conn, error) {
+				attempt++
+				if attempt%2 == 0 {
+					return nil, fmt.Errorf("handshake failed")
+				}
+				return connect()
+			}
+
+			cmd := &SrvCheckCmd{hsAttempts: 4, hsFailWarn: 10, hsFailCrit: 60}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkHandshakes(check, flaky))
+			assertListIsEmpty(t, check.Criticals)
+			assertListEquals(t, check.Warnings, "2 of 4 handshakes failed")
+			assertHasPDItem(t, check, "handshake_success=50%")
+		})
+	})
+}
+
+func TestCheckRoundtrip(t *testing.T) {
+	withJetStream(t, func(_ *server.Server, nc *nats.Conn, mgr *jsm.Manager) {
+		opts().Timeout = time.Second
+		cmd := &SrvCheckCmd{pubSubject: "probe.roundtrip", pubWarning: 500 * time.Millisecond, pubCritical: time.Second}
+
+		t.Run("no stream", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkRoundtrip(check, nc, mgr))
+			assertListIsEmpty(t, check.OKs)
+			assertListEquals(t, check.Criticals, "no stream on probe.roundtrip")
+		})
+
+		stream, err := mgr.NewStream("PROBE", jsm.Subjects("probe.>"), jsm.MemoryStorage())
+		checkErr(t, err, "stream create failed: %v", err)
+
+		_, err = nc.Request("probe.roundtrip", []byte("existing"), time.Second)
+		checkErr(t, err, "publish failed: %v", err)
+
+		t.Run("delivered", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkRoundtrip(check, nc, mgr))
+			assertListIsEmpty(t, check.Criticals)
+			assertListIsEmpty(t, check.Warnings)
+			if len(check.OKs) != 1 || !strings.HasPrefix(check.OKs[0], "delivered by PROBE in") {
+				t.Fatalf("unexpected oks: %v", check.OKs)
+			}
+			assertHasPDItem(t, check, "roundtrip_time=")
+
+			nfo, err := stream.Information()
+			checkErr(t, err, "info failed: %v", err)
+			if nfo.State.Msgs != 2 {
+				t.Fatalf("expected 2 messages got %d", nfo.State.Msgs)
+			}
+			if nfo.State.Consumers != 0 {
+				t.Fatalf("expected the consumer to be removed, got %d consumers", nfo.State.Consumers)
+			}
+		})
+
+		t.Run("purge", func(t *testing.T) {
+			cmd := &SrvCheckCmd{pubSubject: "probe.roundtrip", pubPurge: true}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkRoundtrip(check, nc, mgr))
+			assertListIsEmpty(t, check.Criticals)
+
+			nfo, err := stream.State()
+			checkErr(t, err, "state failed: %v", err)
+			if nfo.Msgs != 0 {
+				t.Fatalf("expected 0 messages got %d", nfo.Msgs)
+			}
+		})
+	})
+}
+
+func TestCheckPublish(t *testing.T) {
+	withJetStream(t, func(_ *server.Server, nc *nats.Conn, mgr *jsm.Manager) {
+		opts().Timeout = time.Second
+		cmd := &SrvCheckCmd{pubSubject: "probe.publish", pubWarning: 500 * time.Millisecond, pubCritical: time.Second}
+
+		t.Run("no stream", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkPublish(check, nc, mgr))
+			assertListIsEmpty(t, check.OKs)
+			assertListEquals(t, check.Criticals, "no stream on probe.publish")
+		})
+
+		stream, err := mgr.NewStream("PROBE", jsm.Subjects("probe.>"), jsm.MemoryStorage())
+		checkErr(t, err, "stream create failed: %v", err)
+
+		t.Run("acknowledged", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkPublish(check, nc, mgr))
+			assertListIsEmpty(t, check.Criticals)
+			assertListIsEmpty(t, check.Warnings)
+			if len(check.OKs) != 1 || !strings.HasPrefix(check.OKs[0], "acknowledged by PROBE in") {
+				t.Fatalf("unexpected oks: %v", check.OKs)
+			}
+			assertHasPDItem(t, check, "ack_time=")
+
+			nfo, err := stream.State()
+			checkErr(t, err, "state failed: %v", err)
+			if nfo.Msgs != 1 {
+				t.Fatalf("expected 1 message got %d", nfo.Msgs)
+			}
+		})
+
+		t.Run("purge", func(t *testing.T) {
+			cmd := &SrvCheckCmd{pubSubject: "probe.publish", pubPurge: true}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkPublish(check, nc, mgr))
+			assertListIsEmpty(t, check.Criticals)
+
+			nfo, err := stream.State()
+			checkErr(t, err, "state failed: %v", err)
+			if nfo.Msgs != 0 {
+				t.Fatalf("expected 0 messages got %d", nfo.Msgs)
+			}
+		})
+	})
+}
+
+func TestCheckResponders(t *testing.T) {
+	withJetStream(t, func(_ *server.Server, nc *nats.Conn, _ *jsm.Manager) {
+		opts().Timeout = 500 * time.Millisecond
+		cmd := &SrvCheckCmd{reqSubject: "service", respIDHeader: "Responder", respRequests: 20, respWarn: 3, respCrit: 1}
+
+		t.Run("invalid thresholds", func(t *testing.T) {
+			cmd := &SrvCheckCmd{reqSubject: "service", respIDHeader: "Responder", respRequests: 1, respWarn: 1, respCrit: 2}
+			err := cmd.checkResponders(&monitor.Result{}, nc)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+
+		t.Run("no responders", func(t *testing.T) {
+			cmd := &SrvCheckCmd{reqSubject: "service", respIDHeader: "Responder", respRequests: 2, respWarn: -1, respCrit: 1}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkResponders(check, nc))
+			assertListEquals(t, check.Criticals, "0 responders on service")
+			assertHasPDItem(t, check, "failed_requests=2")
+		})
+
+		for _, id := range []string{"r1", "r2"} {
+			id := id
+			sub, err := nc.QueueSubscribe("service", "q", func(m *nats.Msg) {
+				msg := nats.NewMsg(m.Reply)
+				msg.Header.Set("Responder", id)
+				m.RespondMsg(msg)
+			})
+			checkErr(t, err, "subscribe failed: %v", err)
+			defer sub.Unsubscribe()
+		}
+
+		t.Run("fewer than expected", func(t *testing.T) {
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkResponders(check, nc))
+			assertListIsEmpty(t, check.Criticals)
+			assertListEquals(t, check.Warnings, "2 responders on service")
+			assertHasPDItem(t, check, "responders=2;3;1", "failed_requests=0")
+		})
+
+		t.Run("expected", func(t *testing.T) {
+			cmd := &SrvCheckCmd{reqSubject: "service", respIDHeader: "Responder", respRequests: 20, respWarn: 2, respCrit: 1}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkResponders(check, nc))
+			assertListIsEmpty(t, check.Warnings)
+			assertListEquals(t, check.OKs, "2 responders on service")
+		})
+	})
+}
+
+func TestCheckKnownServers(t *testing.T) {
+	cmd := &SrvCheckCmd{knownServers: []string{"n1", "NCID2"}}
+
+	t.Run("no servers", func(t *testing.T) {
+		err := cmd.checkKnownServers(&monitor.Result{}, nil)
+		if err == nil || err.Error() != "no servers discovered" {
+			t.Fatalf("expected no servers error: %v", err)
+		}
+	})
+
+	t.Run("known by name or id", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkKnownServers(check, []server.ServerInfo{{Name: "n1", ID: "NCID1"}, {Name: "n2", ID: "NCID2"}}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "2 known servers")
+		assertHasPDItem(t, check, "servers=2", "unknown_servers=0")
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkKnownServers(check, []server.ServerInfo{{Name: "n1", ID: "NCID1"}, {Name: "rogue", ID: "NCID3"}}))
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "1 unknown servers: rogue (NCID3)")
+		assertHasPDItem(t, check, "servers=2", "unknown_servers=1")
+	})
+}
+
+func TestCheckConnectionGrowth(t *testing.T) {
+	cmd := &SrvCheckCmd{connGrowthWarn: 10, connGrowthCrit: 20}
+
+	now := time.Now()
+	samples := func(conns ...int) []*accountThroughputSample {
+		var res []*accountThroughputSample
+		for i, c := range conns {
+			res = append(res, &accountThroughputSample{time: now.Add(time.Duration(i) * 5 * time.Second), conns: c})
+		}
+		return res
+	}
+
+	t.Run("too few samples", func(t *testing.T) {
+		err := cmd.checkConnectionGrowth(&monitor.Result{}, samples(1))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{connGrowthWarn: 20, connGrowthCrit: 10}
+		err := cmd.checkConnectionGrowth(&monitor.Result{}, samples(1, 2))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkConnectionGrowth(check, samples(10, 12, 11, 10, 12)))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "12 connections, 2 gained over 20s")
+		assertHasPDItem(t, check, "connection_growth=2;10;20")
+	})
+
+	t.Run("growth with disconnects", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkConnectionGrowth(check, samples(10, 30, 20, 40)))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkConnectionGrowth(check, samples(10, 15, 15, 25)))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "15 connections gained without disconnects over 15s")
+		assertHasPDItem(t, check, "connection_growth_rate=1")
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkConnectionGrowth(check, samples(10, 20, 30)))
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.Criticals, "20 connections gained without disconnects over 10s")
+	})
+}
+
+func TestCheckHeaderPresence(t *testing.T) {
+	msg := func(hdrs ...string) *api.StoredMsg {
+		if len(hdrs) == 0 {
+			return &api.StoredMsg{}
+		}
+
+		hdr := "NATS/1.0\r\n"
+		for _, h := range hdrs {
+			hdr += h + ": 1\r\n"
+		}
+
+		return &api.StoredMsg{Header: []byte(hdr + "\r\n")}
+	}
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{hdrNames: []string{"Trace"}, hdrMissingWarn: 10, hdrMissingCrit: 5}
+		err := cmd.checkHeaderPresence(&monitor.Result{}, []*api.StoredMsg{msg("Trace")})
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("no messages", func(t *testing.T) {
+		cmd := &SrvCheckCmd{hdrNames: []string{"Trace"}, hdrMissingWarn: -1}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkHeaderPresence(check, nil))
+		assertListEquals(t, check.Criticals, "no messages found")
+	})
+
+	t.Run("all present", func(t *testing.T) {
+		cmd := &SrvCheckCmd{hdrNames: []string{"Trace", "Tenant"}, hdrMissingWarn: -1}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkHeaderPresence(check, []*api.StoredMsg{msg("Trace", "Tenant"), msg("Tenant", "Trace")}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "0 of 2 messages missing headers")
+		assertHasPDItem(t, check, "missing_headers=0%")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		cmd := &SrvCheckCmd{hdrNames: []string{"Trace", "Tenant"}, hdrMissingWarn: -1}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkHeaderPresence(check, []*api.StoredMsg{msg("Trace", "Tenant"), msg("Trace"), msg(), msg("Trace", "Tenant")}))
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "2 of 4 messages missing headers: Tenant, Trace")
+		assertHasPDItem(t, check, "missing_headers=50%")
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		cmd := &SrvCheckCmd{hdrNames: []string{"Trace"}, hdrMissingWarn: 10, hdrMissingCrit: 50}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkHeaderPresence(check, []*api.StoredMsg{msg("Trace"), msg("Trace"), msg()}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "1 of 3 messages missing headers: Trace")
+	})
+
+	t.Run("invalid headers", func(t *testing.T) {
+		cmd := &SrvCheckCmd{hdrNames: []string{"Trace"}, hdrMissingWarn: -1}
+		err := cmd.checkHeaderPresence(&monitor.Result{}, []*api.StoredMsg{msg("Trace"), {Sequence: 2, Header: []byte("garbage")}})
+		if err == nil || !strings.HasPrefix(err.Error(), "invalid headers in message 2") {
+			t.Fatalf("expected an invalid headers error: %v", err)
+		}
+	})
+}
+
+func TestRecentMessages(t *testing.T) {
+	// every even sequence is deleted
+	reads := 0
+	read := func(seq uint64) (*api.StoredMsg, error) {
+		reads++
+		if seq%2 == 0 {
+			return nil, api.ApiError{Code: 404, ErrCode: 10037, Description: "no message found"}
+		}
+		if seq == 99 {
+			return nil, fmt.Errorf("read failed")
+		}
+		return &api.StoredMsg{Sequence: seq}, nil
+	}
+
+	t.Run("sparse", func(t *testing.T) {
+		reads = 0
+		cmd := &SrvCheckCmd{hdrCount: 3}
+		check := &monitor.Result{}
+		msgs, err := cmd.recentMessages(check, api.StreamState{FirstSeq: 1, LastSeq: 10, NumDeleted: 5}, read)
+		assertNoError(t, err)
+		assertListIsEmpty(t, check.Warnings)
+		if len(msgs) != 3 || msgs[0].Sequence != 9 || msgs[2].Sequence != 5 {
+			t.Fatalf("unexpected messages: %v", msgs)
+		}
+		if reads != 6 {
+			t.Fatalf("expected 6 reads got %d", reads)
+		}
+	})
+
+	t.Run("fewer than count", func(t *testing.T) {
+		cmd := &SrvCheckCmd{hdrCount: 10}
+		check := &monitor.Result{}
+		msgs, err := cmd.recentMessages(check, api.StreamState{FirstSeq: 1, LastSeq: 4, NumDeleted: 2}, read)
+		assertNoError(t, err)
+		assertListIsEmpty(t, check.Warnings)
+		if len(msgs) != 2 {
+			t.Fatalf("expected 2 messages got %d", len(msgs))
+		}
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		reads = 0
+		cmd := &SrvCheckCmd{hdrCount: 5}
+		check := &monitor.Result{}
+		msgs, err := cmd.recentMessages(check, api.StreamState{FirstSeq: 1, LastSeq: 1000000, NumDeleted: 900000}, func(seq uint64) (*api.StoredMsg, error) {
+			reads++
+			return nil, api.ApiError{Code: 404, ErrCode: 10037, Description: "no message found"}
+		})
+		assertNoError(t, err)
+		assertListEquals(t, check.Warnings, "found 0 of 5 messages within 1005 reads")
+		if len(msgs) != 0 || reads != 1005 {
+			t.Fatalf("expected 1005 reads and no messages, got %d reads and %d messages", reads, len(msgs))
+		}
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		cmd := &SrvCheckCmd{hdrCount: 5}
+		_, err := cmd.recentMessages(&monitor.Result{}, api.StreamState{FirstSeq: 1, LastSeq: 99}, read)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestCheckAssetVisibility(t *testing.T) {
+	streams := map[string]bool{"ORDERS": true, "KV_CONFIG": true}
+	known := func(stream string) (bool, error) {
+		return streams[stream], nil
+	}
+
+	t.Run("nothing to check", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		err := cmd.checkAssetVisibility(&monitor.Result{}, known)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		cmd := &SrvCheckCmd{visStreams: []string{"ORDERS"}}
+		err := cmd.checkAssetVisibility(&monitor.Result{}, func(string) (bool, error) { return false, fmt.Errorf("timeout") })
+		if err == nil || err.Error() != "timeout" {
+			t.Fatalf("expected timeout error: %v", err)
+		}
+	})
+
+	t.Run("reachable", func(t *testing.T) {
+		cmd := &SrvCheckCmd{visStreams: []string{"ORDERS"}, visBuckets: []string{"CONFIG"}}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAssetVisibility(check, known))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "2 reachable")
+		assertHasPDItem(t, check, "reachable=2")
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		cmd := &SrvCheckCmd{visStreams: []string{"ORDERS", "EVENTS"}, visBuckets: []string{"CONFIG", "ORDERS"}}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAssetVisibility(check, known))
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "2 of 4 unreachable: stream EVENTS, bucket ORDERS")
+		assertHasPDItem(t, check, "expected=4")
+		assertHasPDItem(t, check, "reachable=2")
+	})
+}
+
+func TestCheckAccountLimits(t *testing.T) {
+	limited := func(conns int64, subs int64) *jwt.AccountClaims {
+		claims := jwt.NewAccountClaims("ACCOUNT")
+		claims.Limits.Conn = conns
+		claims.Limits.Subs = subs
+		return claims
+	}
+
+	t.Run("nil data", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		err := cmd.checkAccountLimits(&monitor.Result{}, nil, &accountUsage{})
+		if err == nil || err.Error() != "no account information" {
+			t.Fatalf("expected no account information error: %v", err)
+		}
+
+		err = cmd.checkAccountLimits(&monitor.Result{}, &server.AccountInfo{}, nil)
+		if err == nil || err.Error() != "no account information" {
+			t.Fatalf("expected no account information error: %v", err)
+		}
+	})
+
+	t.Run("no thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{acctConnWarn: -1, acctConnCrit: -1, acctSubsWarn: -1, acctSubsCrit: -1}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountLimits(check, &server.AccountInfo{Claim: limited(10, 100)}, &accountUsage{conns: 5, busiestSubs: 10}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListIsEmpty(t, check.OKs)
+		assertHasPDItem(t, check, "connections=5", "connections_limit=10", "connection_subscriptions=10", "connection_subscriptions_limit=100")
+	})
+
+	cmd := &SrvCheckCmd{acctConnWarn: 70, acctConnCrit: 90, acctSubsWarn: 70, acctSubsCrit: 90}
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{acctConnWarn: 90, acctConnCrit: 70, acctSubsWarn: -1, acctSubsCrit: -1}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountLimits(check, &server.AccountInfo{Claim: limited(10, 100)}, &accountUsage{}))
+		assertListEquals(t, check.Criticals, "connections: invalid thresholds")
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountLimits(check, &server.AccountInfo{}, &accountUsage{conns: 5, busiestSubs: 10}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "5 connections, no limit configured", "10 subscriptions on the busiest connection, no limit configured")
+		assertHasPDItem(t, check, "connections_limit=-1", "connection_subscriptions_limit=-1")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountLimits(check, &server.AccountInfo{Claim: limited(10, 100)}, &accountUsage{conns: 5, busiestSubs: 10}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "5 of 10 connections", "10 of 100 subscriptions on the busiest connection")
+		assertHasPDItem(t, check, "connections_pct=50%;70;90", "connection_subscriptions_pct=10%;70;90")
+	})
+
+	t.Run("warning and critical", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkAccountLimits(check, &server.AccountInfo{Claim: limited(10, 100)}, &accountUsage{conns: 8, busiestSubs: 95}))
+		assertListEquals(t, check.Warnings, "80% of 10 connections")
+		assertListEquals(t, check.Criticals, "95% of 100 subscriptions on the busiest connection")
+		assertListIsEmpty(t, check.OKs)
+	})
+}
+
+// withSystemAccount starts a server with a system account and a USER and OTHER
+// account, nc is connected as the system user
+func withSystemAccount(t *testing.T, cb func(srv *server.Server, nc *nats.Conn)) {
+	t.Helper()
+
+	options.DefaultOptions = &options.Options{Timeout: time.Second}
+	ctx = context.Background()
+
+	conf := filepath.Join(t.TempDir(), "server.conf")
+	err := os.WriteFile(conf, []byte(`
+port: -1
+system_account: SYS
+accounts {
+  SYS: { users: [{user: sys, password: sys}] }
+  USER: { users: [{user: user, password: user}] }
+  OTHER: { users: [{user: other, password: other}] }
+}
+`), 0600)
+	assertNoError(t, err)
+
+	sopts, err := server.ProcessConfigFile(conf)
+	assertNoError(t, err)
+
+	srv, err := server.NewServer(sopts)
+	checkErr(t, err, "could not start server: %v", err)
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		t.Fatalf("nats server did not start")
+	}
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL(), nats.UserInfo("sys", "sys"))
+	checkErr(t, err, "could not connect: %v", err)
+	defer nc.Close()
+
+	cb(srv, nc)
+}
+
+func TestSampleAccountUsage(t *testing.T) {
+	withSystemAccount(t, func(srv *server.Server, nc *nats.Conn) {
+		cmd := &SrvCheckCmd{acctName: "USER"}
+
+		t.Run("idle account", func(t *testing.T) {
+			usage, err := cmd.sampleAccountUsage(nc)
+			assertNoError(t, err)
+			if usage.conns != 0 || usage.busiestSubs != 0 {
+				t.Fatalf("expected no usage got %+v", usage)
+			}
+		})
+
+		t.Run("connected", func(t *testing.T) {
+			unc, err := nats.Connect(srv.ClientURL(), nats.UserInfo("user", "user"))
+			checkErr(t, err, "could not connect: %v", err)
+			defer unc.Close()
+
+			_, err = unc.SubscribeSync("one")
+			assertNoError(t, err)
+			_, err = unc.SubscribeSync("two")
+			assertNoError(t, err)
+			assertNoError(t, unc.Flush())
+
+			usage, err := cmd.sampleAccountUsage(nc)
+			assertNoError(t, err)
+			if usage.conns != 1 || usage.busiestSubs != 2 {
+				t.Fatalf("expected 1 connection with 2 subscriptions got %+v", usage)
+			}
+		})
+	})
+}
+
+func TestSampleAccountThroughput(t *testing.T) {
+	withSystemAccount(t, func(srv *server.Server, nc *nats.Conn) {
+		cmd := &SrvCheckCmd{acctName: "USER"}
+
+		unc, err := nats.Connect(srv.ClientURL(), nats.UserInfo("user", "user"))
+		checkErr(t, err, "could not connect: %v", err)
+		defer unc.Close()
+
+		onc, err := nats.Connect(srv.ClientURL(), nats.UserInfo("other", "other"))
+		checkErr(t, err, "could not connect: %v", err)
+		defer onc.Close()
+
+		for i := 0; i < 10; i++ {
+			assertNoError(t, unc.Publish("test", []byte("x")))
+		}
+		for i := 0; i < 100; i++ {
+			assertNoError(t, onc.Publish("test", []byte("x")))
+		}
+		assertNoError(t, unc.Flush())
+		assertNoError(t, onc.Flush())
+
+		sample, err := cmd.sampleAccountThroughput(nc)
+		assertNoError(t, err)
+		if sample.conns != 1 {
+			t.Fatalf("expected 1 connection got %d", sample.conns)
+		}
+		if sample.received.Msgs != 10 {
+			t.Fatalf("expected 10 received messages got %d", sample.received.Msgs)
+		}
+	})
+}
+
+func TestMatchAccountStats(t *testing.T) {
+	resp := func(name string, stats ...*server.AccountStat) []byte {
+		j, err := json.Marshal(server.ServerAPIResponse{Server: &server.ServerInfo{Name: name}, Data: &server.AccountStatz{Accounts: stats}})
+		checkErr(t, err, "marshal failed: %v", err)
+		return j
+	}
+
+	cmd := &SrvCheckCmd{}
+
+	t.Run("idle account", func(t *testing.T) {
+		stats, err := cmd.matchAccountStats([][]byte{
+			resp("n1", &server.AccountStat{Account: "USER"}),
+			resp("n2"),
+		}, "USER")
+		assertNoError(t, err)
+		if len(stats) != 1 || stats[0].Conns != 0 {
+			t.Fatalf("expected one idle entry got %v", stats)
+		}
+	})
+
+	t.Run("other accounts", func(t *testing.T) {
+		stats, err := cmd.matchAccountStats([][]byte{
+			resp("n1", &server.AccountStat{Account: "USER", Conns: 2}, &server.AccountStat{Account: "OTHER", Conns: 10}),
+			resp("n2", &server.AccountStat{Account: "USER", Conns: 3}),
+		}, "USER")
+		assertNoError(t, err)
+		if len(stats) != 2 || stats[0].Conns+stats[1].Conns != 5 {
+			t.Fatalf("expected 5 USER connections got %v", stats)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		_, err := cmd.matchAccountStats([][]byte{[]byte(`{"error":{"code":500,"description":"failed"}}`)}, "USER")
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestCheckSubjectMapping(t *testing.T) {
+	cmd := &SrvCheckCmd{mapSource: "orders.>", mapDests: map[string]string{"orders.v1.>": "80", "orders.v2.>": "20"}}
+
+	t.Run("nil data", func(t *testing.T) {
+		err := cmd.checkSubjectMapping(&monitor.Result{}, nil)
+		if err.Error() != "no data received" {
+			t.Fatalf("expected no data error: %v", err)
+		}
+	})
+
+	t.Run("invalid weight", func(t *testing.T) {
+		cmd := &SrvCheckCmd{mapSource: "orders.>", mapDests: map[string]string{"orders.v1.>": "x"}}
+		err := cmd.checkSubjectMapping(&monitor.Result{}, &server.AccountInfo{})
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("no mapping", func(t *testing.T) {
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkSubjectMapping(check, &server.AccountInfo{}))
+		assertListEquals(t, check.Criticals, "no mapping for orders.>")
+	})
+
+	t.Run("matching", func(t *testing.T) {
+		check := &monitor.Result{}
+		info := &server.AccountInfo{Mappings: server.ExtMap{"orders.>": {{Subject: "orders.v2.>", Weight: 20}, {Subject: "orders.v1.>", Weight: 80}}}}
+		assertNoError(t, cmd.checkSubjectMapping(check, info))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "Mapping orders.> weights orders.v1.>=80, orders.v2.>=20")
+		assertHasPDItem(t, check, "destinations=2")
+	})
+
+	t.Run("drifted", func(t *testing.T) {
+		check := &monitor.Result{}
+		info := &server.AccountInfo{Mappings: server.ExtMap{"orders.>": {{Subject: "orders.v2.>", Weight: 50}, {Subject: "orders.v1.>", Weight: 50}}}}
+		assertNoError(t, cmd.checkSubjectMapping(check, info))
+		assertListIsEmpty(t, check.OKs)
+		assertListEquals(t, check.Criticals, "Mapping orders.> weights orders.v1.>=50, orders.v2.>=50 expected orders.v1.>=80, orders.v2.>=20")
+	})
+}
+
+func testCertificate(t *testing.T, cn string, dns string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	checkErr(t, err, "key generation failed: %v", err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	if dns == "" {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+		parent = tmpl
+		parentKey = key
+	} else {
+		tmpl.DNSNames = []string{dns}
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	checkErr(t, err, "certificate creation failed: %v", err)
+
+	cert, err := x509.ParseCertificate(der)
+	checkErr(t, err, "certificate parse failed: %v", err)
+
+	return cert, key
+}
+
+func TestCheckTLSChain(t *testing.T) {
+	ca, caKey := testCertificate(t, "Expected CA", "", nil, nil)
+	other, _ := testCertificate(t, "Other CA", "", nil, nil)
+	leaf, _ := testCertificate(t, "nats", "nats.example.net", ca, caKey)
+	expires := "CN=nats expires " + leaf.NotAfter.UTC().Format(time.RFC3339)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	otherRoots := x509.NewCertPool()
+	otherRoots.AddCert(other)
+
+	t.Run("no certificates", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		err := cmd.checkTLSChain(&monitor.Result{}, nil, roots)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("trusted", func(t *testing.T) {
+		cmd := &SrvCheckCmd{tlsHostname: "nats.example.net"}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkTLSChain(check, []*x509.Certificate{leaf}, roots))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "CN=nats issued by CN=Expected CA", expires)
+		assertHasPDItem(t, check, "expiry=")
+	})
+
+	t.Run("unexpected CA", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkTLSChain(check, []*x509.Certificate{leaf}, otherRoots))
+		assertListEquals(t, check.OKs, expires)
+		assertHasPDItem(t, check, "expiry=")
+		if len(check.Criticals) != 1 || !strings.HasPrefix(check.Criticals[0], "CN=nats issued by CN=Expected CA: x509: certificate signed by unknown authority") {
+			t.Fatalf("unexpected criticals: %v", check.Criticals)
+		}
+	})
+
+	t.Run("wrong hostname", func(t *testing.T) {
+		cmd := &SrvCheckCmd{tlsHostname: "other.example.net"}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkTLSChain(check, []*x509.Certificate{leaf}, roots))
+		assertListEquals(t, check.OKs, expires)
+		assertHasPDItem(t, check, "expiry=")
+		if len(check.Criticals) != 1 || !strings.Contains(check.Criticals[0], "not other.example.net") {
+			t.Fatalf("unexpected criticals: %v", check.Criticals)
+		}
+	})
+}
+
+func TestProbeWebsocket(t *testing.T) {
+	options.DefaultOptions = &options.Options{Timeout: time.Second}
+
+	ca, caKey := testCertificate(t, "Websocket CA", "", nil, nil)
+	leaf, leafKey := testCertificate(t, "nats", "localhost", ca, caKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	start := func(t *testing.T, ws server.WebsocketOpts) *server.Server {
+		t.Helper()
+
+		ws.Host = "localhost"
+		ws.Port = -1
+		srv, err := server.NewServer(&server.Options{Port: -1, Websocket: ws})
+		checkErr(t, err, "could not start server: %v", err)
+		go srv.Start()
+		if !srv.ReadyForConnections(10 * time.Second) {
+			t.Fatalf("nats server did not start")
+		}
+		t.Cleanup(srv.Shutdown)
+
+		return srv
+	}
+
+	wsPort := func(t *testing.T, srv *server.Server) int {
+		t.Helper()
+
+		vz, err := srv.Varz(nil)
+		assertNoError(t, err)
+
+		return vz.Websocket.Port
+	}
+
+	t.Run("plaintext", func(t *testing.T) {
+		srv := start(t, server.WebsocketOpts{NoTLS: true, AllowedOrigins: []string{"https://a.example.net"}})
+		target := fmt.Sprintf("ws://localhost:%d", wsPort(t, srv))
+
+		cmd := &SrvCheckCmd{srvWSTLS: true, srvWSOrigins: []string{"https://a.example.net", "https://b.example.net"}}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.probeWebsocket(check, target, nil))
+		assertListEquals(t, check.Criticals, "WebSocket accepted a plaintext handshake", "WebSocket rejected origin https://b.example.net with 403 Forbidden")
+		assertListEquals(t, check.OKs, "WebSocket accepted origin https://a.example.net", "WebSocket rejected disallowed origin https://natscli.invalid with 403 Forbidden")
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		tlsc := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{leaf.Raw}, PrivateKey: leafKey}}}
+		srv := start(t, server.WebsocketOpts{TLSConfig: tlsc, AllowedOrigins: []string{"https://a.example.net"}})
+		target := fmt.Sprintf("wss://localhost:%d", wsPort(t, srv))
+
+		cmd := &SrvCheckCmd{srvWSTLS: true, srvWSOrigins: []string{"https://a.example.net"}}
+		check := &monitor.Result{}
+		assertNoError(t, cmd.probeWebsocket(check, target, &tls.Config{RootCAs: roots}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "WebSocket rejected a plaintext handshake with 400 Bad Request", "WebSocket accepted origin https://a.example.net", "WebSocket rejected disallowed origin https://natscli.invalid with 403 Forbidden")
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		cmd := &SrvCheckCmd{srvWSTLS: true}
+		err := cmd.probeWebsocket(&monitor.Result{}, "nats://localhost:4222", nil)
+		if err == nil || err.Error() != `unsupported scheme "nats"` {
+			t.Fatalf("expected unsupported scheme error got %v", err)
+		}
+	})
+}
+
+func TestProbeMQTTPlaintext(t *testing.T) {
+	options.DefaultOptions = &options.Options{Timeout: time.Second}
+
+	ca, caKey := testCertificate(t, "MQTT CA", "", nil, nil)
+	leaf, leafKey := testCertificate(t, "nats", "localhost", ca, caKey)
+
+	start := func(t *testing.T, tlsc *tls.Config) string {
+		t.Helper()
+
+		srv, err := server.NewServer(&server.Options{
+			ServerName: "mqtt",
+			Port:       -1,
+			JetStream:  true,
+			StoreDir:   t.TempDir(),
+			MQTT:       server.MQTTOpts{Host: "localhost", Port: -1, TLSConfig: tlsc},
+		})
+		checkErr(t, err, "could not start server: %v", err)
+		go srv.Start()
+		if !srv.ReadyForConnections(10 * time.Second) {
+			t.Fatalf("nats server did not start")
+		}
+		t.Cleanup(srv.Shutdown)
+
+		vz, err := srv.Varz(nil)
+		assertNoError(t, err)
+
+		return fmt.Sprintf("localhost:%d", vz.MQTT.Port)
+	}
+
+	t.Run("plaintext", func(t *testing.T) {
+		check := &monitor.Result{}
+		(&SrvCheckCmd{}).probeMQTTPlaintext(check, start(t, nil))
+		assertListEquals(t, check.Criticals, "MQTT accepted a plaintext connection")
+		assertListIsEmpty(t, check.OKs)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		check := &monitor.Result{}
+		(&SrvCheckCmd{}).probeMQTTPlaintext(check, start(t, &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{leaf.Raw}, PrivateKey: leafKey}}}))
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.OKs, "MQTT rejected a plaintext connection: EOF")
+	})
+}
+
+func TestTLSRoots(t *testing.T) {
+	ca, _ := testCertificate(t, "Context CA", "", nil, nil)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0600)
+	assertNoError(t, err)
+
+	cmd := &SrvCheckCmd{}
+
+	t.Run("system roots", func(t *testing.T) {
+		roots, err := cmd.tlsRoots(&nats.Options{}, nil)
+		assertNoError(t, err)
+		if roots != nil {
+			t.Fatalf("expected system roots")
+		}
+	})
+
+	t.Run("context CA", func(t *testing.T) {
+		o := &nats.Options{}
+		assertNoError(t, nats.RootCAs(caFile)(o))
+
+		roots, err := cmd.tlsRoots(o, nil)
+		assertNoError(t, err)
+		expected := x509.NewCertPool()
+		expected.AddCert(ca)
+		if roots == nil || !roots.Equal(expected) {
+			t.Fatalf("expected the context CA")
+		}
+	})
+
+	t.Run("supplied CA", func(t *testing.T) {
+		o := &nats.Options{}
+		assertNoError(t, nats.RootCAs(caFile)(o))
+
+		supplied := x509.NewCertPool()
+		roots, err := cmd.tlsRoots(o, supplied)
+		assertNoError(t, err)
+		if roots != supplied {
+			t.Fatalf("expected the supplied CA")
+		}
+	})
+}
+
+func TestCheckCertificateExpiry(t *testing.T) {
+	ca, caKey := testCertificate(t, "Expected CA", "", nil, nil)
+	leaf, _ := testCertificate(t, "nats", "nats.example.net", ca, caKey)
+	expires := leaf.NotAfter.UTC().Format(time.RFC3339)
+
+	t.Run("no thresholds", func(t *testing.T) {
+		cmd := &SrvCheckCmd{}
+		check := &monitor.Result{}
+		cmd.checkCertificateExpiry(check, "expiry", leaf)
+		assertListEquals(t, check.OKs, "CN=nats expires "+expires)
+		assertHasPDItem(t, check, "expiry=")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		cmd := &SrvCheckCmd{tlsValidityWarn: 10 * time.Minute, tlsValidityCrit: 5 * time.Minute}
+		check := &monitor.Result{}
+		cmd.checkCertificateExpiry(check, "expiry", leaf)
+		assertListIsEmpty(t, check.Criticals)
+		assertListIsEmpty(t, check.Warnings)
+		assertListEquals(t, check.OKs, "CN=nats expires "+expires)
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		cmd := &SrvCheckCmd{tlsValidityWarn: 2 * time.Hour, tlsValidityCrit: 5 * time.Minute}
+		check := &monitor.Result{}
+		cmd.checkCertificateExpiry(check, "expiry", leaf)
+		assertListIsEmpty(t, check.Criticals)
+		assertListEquals(t, check.Warnings, "CN=nats expires "+expires)
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		cmd := &SrvCheckCmd{tlsValidityWarn: 3 * time.Hour, tlsValidityCrit: 2 * time.Hour}
+		check := &monitor.Result{}
+		cmd.checkCertificateExpiry(check, "expiry", leaf)
+		assertListEquals(t, check.Criticals, "CN=nats expires "+expires)
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		cmd := &SrvCheckCmd{tlsValidityWarn: 2 * time.Hour}
+
+		check := &monitor.Result{}
+		assertNoError(t, cmd.checkClientCertificate(check, ""))
+		assertListEquals(t, check.Criticals, "no client certificate configured")
+
+		file := filepath.Join(t.TempDir(), "cert.pem")
+		err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw}), 0600)
+		checkErr(t, err, "write failed: %v", err)
+
+		check = &monitor.Result{}
+		assertNoError(t, cmd.checkClientCertificate(check, file))
+		assertListEquals(t, check.Warnings, "CN=nats expires "+expires)
+		assertHasPDItem(t, check, "client_expiry=")
+	})
+}
+
+func TestCheckObjectStoreStatus(t *testing.T) {
+	withJetStream(t, func(_ *server.Server, nc *nats.Conn, mgr *jsm.Manager) {
+		js, err := nc.JetStream()
+		checkErr(t, err, "js failed: %v", err)
+
+		obs, err := js.CreateObjectStore(&nats.ObjectStoreConfig{Bucket: "ARTIFACTS"})
+		checkErr(t, err, "create failed: %v", err)
+
+		stream, err := mgr.LoadStream("OBJ_ARTIFACTS")
+		checkErr(t, err, "stream load failed: %v", err)
+
+		t.Run("empty", func(t *testing.T) {
+			cmd := &SrvCheckCmd{objBucket: "ARTIFACTS", objObjectsWarn: 1, objObjectsCrit: 0, objBytesWarn: -1, objBytesCrit: -1}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkObjectStoreStatus(check, obs, stream))
+			assertListEquals(t, check.Criticals, "0 objects")
+			assertHasPDItem(t, check, "objects=0;1", "bytes=0B")
+		})
+
+		_, err = obs.PutString("release", strings.Repeat("x", 1024))
+		checkErr(t, err, "put failed: %v", err)
+
+		_, err = obs.PutString("deleted", "x")
+		checkErr(t, err, "put failed: %v", err)
+		checkErr(t, obs.Delete("deleted"), "delete failed")
+
+		t.Run("bucket", func(t *testing.T) {
+			cmd := &SrvCheckCmd{objBucket: "ARTIFACTS", objObjectsWarn: -1, objObjectsCrit: -1, objBytesWarn: 1024, objBytesCrit: 1 << 20}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkObjectStoreStatus(check, obs, stream))
+			assertListIsEmpty(t, check.Criticals)
+			if len(check.Warnings) != 1 || !strings.HasSuffix(check.Warnings[0], " bytes") {
+				t.Fatalf("unexpected warnings: %v", check.Warnings)
+			}
+			assertListEquals(t, check.OKs, "bucket ARTIFACTS")
+			assertHasPDItem(t, check, "objects=1")
+		})
+
+		t.Run("object", func(t *testing.T) {
+			cmd := &SrvCheckCmd{objBucket: "ARTIFACTS", objName: "release", objMinSize: 512, objMaxSize: 2048, objObjectsWarn: -1, objObjectsCrit: -1, objBytesWarn: -1, objBytesCrit: -1}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkObjectStoreStatus(check, obs, stream))
+			assertListIsEmpty(t, check.Criticals)
+			assertListEquals(t, check.OKs, "object release of 1.0 KiB found")
+		})
+
+		t.Run("object size", func(t *testing.T) {
+			cmd := &SrvCheckCmd{objBucket: "ARTIFACTS", objName: "release", objMinSize: 2048, objObjectsWarn: -1, objObjectsCrit: -1, objBytesWarn: -1, objBytesCrit: -1}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkObjectStoreStatus(check, obs, stream))
+			assertListEquals(t, check.Criticals, "object release of 1.0 KiB is smaller than 2.0 KiB")
+
+			cmd = &SrvCheckCmd{objBucket: "ARTIFACTS", objName: "release", objMaxSize: 512, objObjectsWarn: -1, objObjectsCrit: -1, objBytesWarn: -1, objBytesCrit: -1}
+			check = &monitor.Result{}
+			assertNoError(t, cmd.checkObjectStoreStatus(check, obs, stream))
+			assertListEquals(t, check.Criticals, "object release of 1.0 KiB is larger than 512 B")
+		})
+
+		t.Run("missing object", func(t *testing.T) {
+			cmd := &SrvCheckCmd{objBucket: "ARTIFACTS", objName: "deleted", objObjectsWarn: -1, objObjectsCrit: -1, objBytesWarn: -1, objBytesCrit: -1}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkObjectStoreStatus(check, obs, stream))
+			assertListEquals(t, check.Criticals, "object deleted not found")
+
+			cmd = &SrvCheckCmd{objBucket: "ARTIFACTS", objName: "missing", objObjectsWarn: -1, objObjectsCrit: -1, objBytesWarn: -1, objBytesCrit: -1}
+			check = &monitor.Result{}
+			assertNoError(t, cmd.checkObjectStoreStatus(check, obs, stream))
+			assertListEquals(t, check.Criticals, "object missing not found")
+		})
+	})
+}
+
+func TestCheckObjectIntegrity(t *testing.T) {
+	withJetStream(t, func(_ *server.Server, nc *nats.Conn, _ *jsm.Manager) {
+		js, err := nc.JetStream()
+		checkErr(t, err, "js failed: %v", err)
+
+		obs, err := js.CreateObjectStore(&nats.ObjectStoreConfig{Bucket: "ARTIFACTS"})
+		checkErr(t, err, "create failed: %v", err)
+
+		_, err = obs.PutString("release", strings.Repeat("x", 1024))
+		checkErr(t, err, "put failed: %v", err)
+
+		t.Run("missing", func(t *testing.T) {
+			cmd := &SrvCheckCmd{objName: "missing"}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkObjectIntegrity(check, obs))
+			assertListEquals(t, check.Criticals, "object missing not found")
+		})
+
+		t.Run("verified", func(t *testing.T) {
+			cmd := &SrvCheckCmd{objName: "release"}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkObjectIntegrity(check, obs))
+			assertListIsEmpty(t, check.Criticals)
+			if len(check.OKs) != 1 || !strings.HasPrefix(check.OKs[0], "object release of 1.0 KiB verified in") {
+				t.Fatalf("unexpected oks: %v", check.OKs)
+			}
+			assertHasPDItem(t, check, "size=1024B", "read_time=")
+		})
+
+		t.Run("too large", func(t *testing.T) {
+			cmd := &SrvCheckCmd{objName: "release", objMaxSize: 512}
+			check := &monitor.Result{}
+			assertNoError(t, cmd.checkObjectIntegrity(check, obs))
+			assertListIsEmpty(t, check.Criticals)
+			assertListEquals(t, check.OKs, "object release of 1.0 KiB not verified, larger than 512 B")
+		})
+	})
+}
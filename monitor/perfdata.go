@@ -21,6 +21,7 @@ import (
 type PerfDataItem struct {
 	Help  string  `json:"-"`
 	Name  string  `json:"name"`
+	Item  string  `json:"item,omitempty"`
 	Value float64 `json:"value"`
 	Warn  float64 `json:"warning"`
 	Crit  float64 `json:"critical"`
@@ -38,13 +39,23 @@ func (p PerfData) String() string {
 	return strings.TrimSpace(strings.Join(res, " "))
 }
 
+// label is the name of the item, qualified by Item when one is set so that
+// several items sharing a name can be told apart
+func (i *PerfDataItem) label() string {
+	if i.Item == "" {
+		return i.Name
+	}
+
+	return fmt.Sprintf("%s[%s]", i.Name, i.Item)
+}
+
 func (i *PerfDataItem) String() string {
 	valueFmt := "%0.0f"
 	if i.Unit == "s" {
 		valueFmt = "%0.4f"
 	}
 
-	pd := fmt.Sprintf("%s="+valueFmt, i.Name, i.Value)
+	pd := fmt.Sprintf("%s="+valueFmt, i.label(), i.Value)
 	if i.Unit != "" {
 		pd = pd + i.Unit
 	}
@@ -138,7 +138,7 @@ func (r *Result) renderHuman() string {
 	tblWriter.AppendHeader(table.Row{"Metric", "Value", "Unit", "Critical Threshold", "Warning Threshold", "Description"})
 	lines = 0
 	for _, pd := range r.PerfData {
-		tblWriter.AppendRow(table.Row{pd.Name, f(pd.Value), pd.Unit, f(pd.Crit), f(pd.Warn), pd.Help})
+		tblWriter.AppendRow(table.Row{pd.label(), f(pd.Value), pd.Unit, f(pd.Crit), f(pd.Warn), pd.Help})
 		lines++
 	}
 	if lines > 0 {
@@ -162,18 +162,29 @@ func (r *Result) renderPrometheus() string {
 	prometheus.DefaultGatherer = registry
 
 	sname := strings.ReplaceAll(r.Name, `"`, `.`)
+	gauges := map[string]*prometheus.GaugeVec{}
 	for _, pd := range r.PerfData {
-		help := fmt.Sprintf("Data about the NATS CLI check %s", r.Check)
-		if pd.Help != "" {
-			help = pd.Help
+		gauge, ok := gauges[pd.Name]
+		if !ok {
+			help := fmt.Sprintf("Data about the NATS CLI check %s", r.Check)
+			if pd.Help != "" {
+				help = pd.Help
+			}
+
+			gauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(r.NameSpace, r.Check, pd.Name),
+				Help: help,
+			}, []string{"item"})
+			prometheus.MustRegister(gauge)
+			gauges[pd.Name] = gauge
 		}
 
-		gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Name: prometheus.BuildFQName(r.NameSpace, r.Check, pd.Name),
-			Help: help,
-		}, []string{"item"})
-		prometheus.MustRegister(gauge)
-		gauge.WithLabelValues(sname).Set(pd.Value)
+		item := sname
+		if pd.Item != "" {
+			item = strings.ReplaceAll(pd.Item, `"`, `.`)
+		}
+
+		gauge.WithLabelValues(item).Set(pd.Value)
 	}
 
 	status := prometheus.NewGaugeVec(prometheus.GaugeOpts{